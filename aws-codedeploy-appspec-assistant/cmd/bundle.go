@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+var bundleOutFilePath string
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Build a reproducible revision bundle from an AppSpec file",
+	Long: `Validate an AppSpec file, collect the files sources and hook scripts it references from its directory,
+and write a .zip or .tgz bundle with the AppSpec at its root. Scripts of Linux AppSpecs are made executable.
+Entries are sorted and have fixed timestamps and owners, so the same content always gives the same bytes (and MD5/ETag).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.BundleRevision(filePath, bundleOutFilePath, computePlatform)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+
+	bundleCmd.PersistentFlags().StringVar(&filePath, "appspec", "", "AppSpec file to bundle, the files it references are relative to its directory")
+	bundleCmd.PersistentFlags().StringVar(&bundleOutFilePath, "out", "", "Bundle to write (.zip, .tgz, or .tar.gz)")
+	bundleCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of the AppSpec (server, lambda, ecs), detected from the AppSpec if not set")
+
+	bundleCmd.MarkPersistentFlagRequired("appspec")
+	bundleCmd.MarkPersistentFlagRequired("out")
+}
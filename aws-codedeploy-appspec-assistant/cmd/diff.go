@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff <old AppSpec file> <new AppSpec file>",
+	Short: "Compare two revisions of a CodeDeploy AppSpec file",
+	Long: `Compare two revisions of a CodeDeploy AppSpec file by their content instead of their text.
+Reports meaningful changes (hooks, timeouts, files, permissions, task definitions, versions, ...) ordered by risk.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.DiffAppSpecs(args[0], args[1], computePlatform)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of both AppSpec files (server, lambda, ecs)")
+
+	diffCmd.MarkPersistentFlagRequired("computePlatform")
+}
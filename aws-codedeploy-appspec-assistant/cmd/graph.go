@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+var graphOutFilePath string
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Render the CodeDeploy lifecycle of an AppSpec file as a Mermaid or Graphviz DOT diagram",
+	Long: `Render the lifecycle events of the compute platform of an AppSpec file (server, ECS, or Lambda) in order,
+highlighting the events that have hooks and the functions or scripts they point at, next to the resources or files the deployment installs.
+The diagram is printed, or written to --out to embed it in READMEs and runbooks.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.GraphAppSpec(filePath, computePlatform, graphFormat, graphOutFilePath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.PersistentFlags().StringVar(&filePath, "filePath", "", "FilePath of the AppSpec file")
+	graphCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of the AppSpec (server, lambda, ecs), detected from the AppSpec if not set")
+	graphCmd.PersistentFlags().StringVar(&graphFormat, "format", "mermaid", "Diagram format: mermaid or dot")
+	graphCmd.PersistentFlags().StringVar(&graphOutFilePath, "out", "", "File to write the diagram to instead of printing it")
+
+	graphCmd.MarkPersistentFlagRequired("filePath")
+}
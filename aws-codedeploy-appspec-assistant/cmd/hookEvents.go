@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+var hookEventsOutDirPath string
+
+// hookEventsCmd represents the hook-events command
+var hookEventsCmd = &cobra.Command{
+	Use:   "hook-events",
+	Short: "Generate sample lifecycle hook events for the hook functions of an ECS or Lambda AppSpec file",
+	Long: `Generate the event CodeDeploy invokes each hook function of an ECS or Lambda AppSpec file with
+(DeploymentId and LifecycleEventHookExecutionId), in lifecycle order, and a run-hook-events.sh script
+that invokes the functions locally with them (sam local invoke by default).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.GenerateHookEvents(filePath, computePlatform, hookEventsOutDirPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hookEventsCmd)
+
+	hookEventsCmd.PersistentFlags().StringVar(&filePath, "filePath", "", "FilePath of the AppSpec file")
+	hookEventsCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of AppSpec file (lambda, ecs)")
+	hookEventsCmd.PersistentFlags().StringVar(&hookEventsOutDirPath, "out", "hook-events", "Directory to write the events and run-hook-events.sh to")
+
+	hookEventsCmd.MarkPersistentFlagRequired("filePath")
+	hookEventsCmd.MarkPersistentFlagRequired("computePlatform")
+}
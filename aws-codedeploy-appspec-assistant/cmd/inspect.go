@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Inspect a revision bundle and validate its AppSpec",
+	Long: `Open a revision archive (.zip, .tgz, .tar.gz, or .tar) or directory, check the AppSpec is at its root
+(no wrapping top-level folder), report bundle issues such as more than one AppSpec, appspec.yaml instead of appspec.yml,
+and symlinks, and validate the AppSpec. Server AppSpecs are also checked against the files in the bundle.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.InspectRevision(revisionPath, computePlatform)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+
+	inspectCmd.PersistentFlags().StringVar(&revisionPath, "revision", "", "Revision .zip, .tgz, .tar.gz, or .tar archive or directory")
+	inspectCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of the AppSpec (server, lambda, ecs), detected from the AppSpec if not set")
+
+	inspectCmd.MarkPersistentFlagRequired("revision")
+}
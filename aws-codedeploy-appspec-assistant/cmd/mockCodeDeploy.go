@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var mockCodeDeployHost string
+var mockCodeDeployPort int
+var mockCodeDeployEndpointHost string
+var mockCodeDeployInvokeCmd string
+var mockCodeDeployHookTimeout time.Duration
+
+// mockCodeDeployCmd represents the mock-codedeploy command
+var mockCodeDeployCmd = &cobra.Command{
+	Use:   "mock-codedeploy",
+	Short: "Run the hook functions of an ECS or Lambda AppSpec file against a local CodeDeploy API",
+	Long: `Serve a local stand-in for the CodeDeploy API (PutLifecycleEventHookExecutionStatus and GetDeployment)
+and invoke the hook functions of an ECS or Lambda AppSpec file in lifecycle order, the way CodeDeploy does.
+Each hook must report Succeeded for the next hook to run. The invoke command gets the endpoint in AWS_ENDPOINT_URL_CODEDEPLOY,
+and {envVars} is a sam local invoke --env-vars file that sets it in the function container.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.MockCodeDeploy(filePath, computePlatform, mockCodeDeployHost, mockCodeDeployPort, mockCodeDeployEndpointHost, mockCodeDeployInvokeCmd, mockCodeDeployHookTimeout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mockCodeDeployCmd)
+
+	mockCodeDeployCmd.PersistentFlags().StringVar(&filePath, "filePath", "", "FilePath of the AppSpec file")
+	mockCodeDeployCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of AppSpec file (lambda, ecs)")
+	mockCodeDeployCmd.PersistentFlags().StringVar(&mockCodeDeployHost, "host", "127.0.0.1", "Address to serve the CodeDeploy API on (0.0.0.0 for containers on a Linux host)")
+	mockCodeDeployCmd.PersistentFlags().IntVar(&mockCodeDeployPort, "port", 4599, "Local port to serve the CodeDeploy API on (0 picks a free port)")
+	mockCodeDeployCmd.PersistentFlags().StringVar(&mockCodeDeployEndpointHost, "endpoint-host", "host.docker.internal", "Host the hook functions reach the CodeDeploy API with (127.0.0.1 when they do not run in a container)")
+	mockCodeDeployCmd.PersistentFlags().StringVar(&mockCodeDeployInvokeCmd, "invoke-cmd", "sam local invoke {function} --event {event} --env-vars {envVars}", "Command that invokes a hook function. {function}, {event}, {endpoint}, and {envVars} are replaced")
+	mockCodeDeployCmd.PersistentFlags().DurationVar(&mockCodeDeployHookTimeout, "hook-timeout", 5*time.Minute, "How long to wait for a hook to report its status after the invoke command exits")
+
+	mockCodeDeployCmd.MarkPersistentFlagRequired("filePath")
+	mockCodeDeployCmd.MarkPersistentFlagRequired("computePlatform")
+}
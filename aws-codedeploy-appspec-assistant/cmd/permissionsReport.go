@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+// permissionsReportCmd represents the permissions-report command
+var permissionsReportCmd = &cobra.Command{
+	Use:   "permissions-report",
+	Short: "Show the owner, group, mode, and acls every file of an EC2/On-Prem revision ends up with",
+	Long: `Apply the permissions entries of an EC2/On-Prem (server) AppSpec, in the order they are listed, to the files the files section
+copies from the revision, and print the resulting owner, group, mode, and acls of every deployed file and directory,
+with the entries that apply to it. Files are owned by root:root and keep the mode they have in the revision unless an entry sets them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.ReportServerPermissions(revisionPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(permissionsReportCmd)
+
+	permissionsReportCmd.PersistentFlags().StringVar(&revisionPath, "revision", "", "Revision directory or .zip, .tgz, or .tar archive with the AppSpec at its root")
+
+	permissionsReportCmd.MarkPersistentFlagRequired("revision")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+var planFilesStagingDirPath string
+
+// planFilesCmd represents the plan-files command
+var planFilesCmd = &cobra.Command{
+	Use:   "plan-files",
+	Short: "Show where the files section of an EC2/On-Prem revision puts every file on the instance",
+	Long: `Resolve every files source/destination pair of an EC2/On-Prem (server) AppSpec against the revision directory
+and print the resulting on-instance file tree, including how a source of / or a directory source expands.
+With --staging-dir the files are also copied into that directory (instance paths are put below it).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.PlanServerFiles(revisionPath, planFilesStagingDirPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planFilesCmd)
+
+	planFilesCmd.PersistentFlags().StringVar(&revisionPath, "revision", "", "Revision directory or .zip, .tgz, or .tar archive with the AppSpec at its root")
+	planFilesCmd.PersistentFlags().StringVar(&planFilesStagingDirPath, "staging-dir", "", "Directory to copy the files into, as they would land on the instance")
+
+	planFilesCmd.MarkPersistentFlagRequired("revision")
+}
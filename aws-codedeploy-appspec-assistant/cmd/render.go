@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+var baseFilePath string
+var overlayFilePaths []string
+var outFilePath string
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a CodeDeploy AppSpec file from a base file and environment overlays",
+	Long: `Render a CodeDeploy AppSpec file from a base AppSpec file and one or more overlay files.
+Resources, Hooks, files, and permissions are merged item by item, other values are replaced by the overlay.
+The rendered AppSpec file is validated before it is written.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.RenderAppSpec(baseFilePath, overlayFilePaths, outFilePath, computePlatform)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.PersistentFlags().StringVar(&baseFilePath, "base", "", "FilePath of the base AppSpec file")
+	renderCmd.PersistentFlags().StringSliceVar(&overlayFilePaths, "overlay", nil, "FilePath of an overlay file, applied in order when repeated")
+	renderCmd.PersistentFlags().StringVar(&outFilePath, "out", "", "FilePath to write the rendered AppSpec file to (appspec.yml or appspec.json)")
+	renderCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of AppSpec file (server, lambda, ecs)")
+
+	renderCmd.MarkPersistentFlagRequired("base")
+	renderCmd.MarkPersistentFlagRequired("overlay")
+	renderCmd.MarkPersistentFlagRequired("out")
+	renderCmd.MarkPersistentFlagRequired("computePlatform")
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+var revisionPath string
+var simulateSandboxDirPath string
+var simulateWithLoadBalancer bool
+var simulateApplicationName string
+var simulateDeploymentGroupName string
+var simulateRunScriptsOnHost bool
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate an EC2/On-Prem deployment of a revision by running its hook scripts in lifecycle order",
+	Long: `Copy the files of an EC2/On-Prem (server) revision into a sandbox root, apply the modes of its permissions,
+and run the hook scripts in the order the CodeDeploy agent runs them, with the agent's environment variables
+(LIFECYCLE_EVENT, DEPLOYMENT_ID, APPLICATION_NAME, DEPLOYMENT_GROUP_NAME), SANDBOX_ROOT, and each script's timeout.
+The revision is the unpacked bundle directory with the AppSpec at its root.
+Only the files are put in the sandbox: the scripts run unconfined on this machine as the current user,
+so --run-scripts-on-host is required to run them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.SimulateServerDeployment(revisionPath, simulateSandboxDirPath, simulateWithLoadBalancer, simulateApplicationName, simulateDeploymentGroupName, simulateRunScriptsOnHost)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+
+	simulateCmd.PersistentFlags().StringVar(&revisionPath, "revision", "", "Directory of the revision with appspec.yml or appspec.json at its root")
+	simulateCmd.PersistentFlags().StringVar(&simulateSandboxDirPath, "sandbox", "", "Directory the instance paths are put below (default a new temp directory)")
+	simulateCmd.PersistentFlags().BoolVar(&simulateWithLoadBalancer, "load-balancer", false, "Also run the traffic hooks of deployments with a load balancer")
+	simulateCmd.PersistentFlags().StringVar(&simulateApplicationName, "application-name", "", "APPLICATION_NAME for the scripts")
+	simulateCmd.PersistentFlags().StringVar(&simulateDeploymentGroupName, "deployment-group-name", "", "DEPLOYMENT_GROUP_NAME for the scripts")
+
+	simulateCmd.PersistentFlags().BoolVar(&simulateRunScriptsOnHost, "run-scripts-on-host", false, "Confirm the hook scripts may run on this machine as the current user, outside the sandbox")
+
+	simulateCmd.MarkPersistentFlagRequired("revision")
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"aws-codedeploy-appspec-assistant/pkg"
+
+	"github.com/spf13/cobra"
+)
+
+// timelineCmd represents the timeline command
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show the lifecycle events of an EC2/On-Prem AppSpec file in order, and how long its scripts can run at most",
+	Long: `Render the lifecycle events of an EC2/On-Prem (server) AppSpec file in the order the CodeDeploy agent runs them,
+with each script, its runas user and timeout, and add up the timeouts into the worst-case duration per instance:
+how long a deployment can hang before CodeDeploy fails it. Scripts without a timeout count with the default of 3600 seconds.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assistant.ShowServerTimeline(filePath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+
+	timelineCmd.PersistentFlags().StringVar(&filePath, "filePath", "", "FilePath of the AppSpec file")
+
+	timelineCmd.PersistentFlags().StringVar(&assistant.ServerDeploymentType, "deployment-type", "", "in-place (default) or blue-green")
+	timelineCmd.PersistentFlags().BoolVar(&assistant.ServerWithLoadBalancer, "load-balancer", false, "The deployment uses a load balancer (blue-green deployments always do)")
+	timelineCmd.PersistentFlags().StringVar(&assistant.ServerDeploymentGroupFilePath, "deployment-group-file", "", "Saved get-deployment-group output to read the deployment type and load balancer from")
+
+	timelineCmd.MarkPersistentFlagRequired("filePath")
+}
@@ -27,6 +27,23 @@ func init() {
 	validateCmd.PersistentFlags().StringVar(&filePath, "filePath", "", "FilePath of AppSpec file to validate")
 	validateCmd.PersistentFlags().StringVar(&computePlatform, "computePlatform", "", "computePlatform of AppSpec file (server, lambda, ecs)")
 
+	validateCmd.PersistentFlags().BoolVar(&assistant.FixAppSpec, "fix", false, "Apply safe automatic fixes to the AppSpec file before validating it")
+	validateCmd.PersistentFlags().BoolVar(&assistant.PreviewAppSpecFixes, "diff", false, "Preview the automatic fixes as a diff without writing them")
+
+	validateCmd.PersistentFlags().BoolVar(&assistant.SubstituteAppSpecVariables, "substitute", false, "Substitute ${VAR} and {{ .Var }} placeholders from environment variables before validating")
+	validateCmd.PersistentFlags().StringVar(&assistant.AppSpecValuesFilePath, "values", "", "YAML or JSON file of placeholder values to substitute (falls back to environment variables)")
+
+	validateCmd.PersistentFlags().BoolVar(&assistant.EcsPipelineMode, "pipeline-mode", false, "ECS only: validate for the CodePipeline CodeDeployToECS action (<TASK_DEFINITION> placeholder and sibling taskdef.json)")
+	validateCmd.PersistentFlags().StringVar(&assistant.EcsTaskDefinitionFilePath, "task-definition-file", "", "ECS only: task definition JSON (taskdef.json or describe-task-definition output) to cross-check the AppSpec against")
+
+	validateCmd.PersistentFlags().StringSliceVar(&assistant.LambdaInventoryFilePaths, "lambda-inventory", nil, "Lambda only: saved list-versions-by-function or list-aliases output to cross-check the AppSpec against (repeatable)")
+
+	validateCmd.PersistentFlags().StringVar(&assistant.ServerRevisionPath, "revision", "", "Server only: revision directory or .zip, .tgz, or .tar archive the files sources and script locations must exist in")
+
+	validateCmd.PersistentFlags().StringVar(&assistant.ServerDeploymentType, "deployment-type", "", "Server only: in-place or blue-green, to show which hooks run on which instances")
+	validateCmd.PersistentFlags().BoolVar(&assistant.ServerWithLoadBalancer, "load-balancer", false, "Server only: the deployment uses a load balancer (blue-green deployments always do)")
+	validateCmd.PersistentFlags().StringVar(&assistant.ServerDeploymentGroupFilePath, "deployment-group-file", "", "Server only: saved get-deployment-group output to read the deployment type and load balancer from")
+
 	validateCmd.MarkFlagRequired("filePath")
 	validateCmd.MarkFlagRequired("computePlatform")
 }
@@ -14,6 +14,183 @@ const (
 	ComputePlatformErr            = "computePlatform must be server, lambda, or ecs"
 	InvalidFileNameOrExtensionErr = "File must be named appspec and file extension must be .json or .yml (appspec.json or appspec.yml)"
 
+	UnsupportedAppSpecNodeErr = "Unsupported YAML node kind in AppSpec: %v"
+
+	// Placeholders
+	InvalidAppSpecVariablesErr    = "All placeholders in the AppSpec must be resolved"
+	InvalidAppSpecPlaceholdersErr = "The AppSpec still contains placeholder values that must be filled in"
+	InvalidValuesFileErr          = "Values file %s is invalid, it must be a map of names to values: %v"
+
+	UnresolvedAppSpecVariablesErr  = "\nERROR CAUSE: No value in the values file or the environment for the placeholders:"
+	UnfilledAppSpecPlaceholderErr  = "\nERROR CAUSE: Placeholder value was never filled in:"
+	SubstitutedAppSpecVariablesMsg = "Substituted %d values in the AppSpec\n"
+
+	// Hooks (ECS and Lambda)
+	InvalidHookFunctionErr        = "\nERROR CAUSE: Hook value must be a Lambda function name, partial ARN, or ARN (with an optional version or alias). Found: %v for hook: %v\n"
+	MissingHookFunctionPrefixWarn = "WARNING: The CodeDeploy managed policies only allow invoking hook functions whose name starts with %v. Found: %v for hook: %v\n"
+	DuplicateHooksErr             = "\nERROR CAUSE: Each hook can only be listed once in Hooks. Listed more than once:"
+
+	//
+	// Fix
+	//
+
+	NoAppSpecFixesMsg      = "\nNo automatic fixes needed"
+	PreviewAppSpecFixesMsg = "\nPreview only. Run with --fix to write the fixes to the AppSpec file"
+	WroteAppSpecFixesMsg   = "\nApplied %d fixes to %s\n"
+
+	//
+	// Diff
+	//
+
+	NoAppSpecChangesMsg      = "No meaningful changes between the AppSpec files"
+	AppSpecChangesHeaderMsg  = "AppSpec changes from %s to %s (most risky first):\n\n"
+	AppSpecChangesSummaryMsg = "\nSummary: %d HIGH, %d MEDIUM, %d LOW risk changes\n"
+
+	//
+	// Render
+	//
+
+	InvalidOverlayFileErr     = "Overlay file %s is invalid: %v"
+	InvalidRenderedAppSpecErr = "\nERROR: The rendered AppSpec is invalid and was not written"
+
+	//
+	// Hook events
+	//
+
+	UnsupportedHookEventsComputePlatformErr = "hook-events only supports ecs and lambda AppSpec files, server hooks are scripts"
+	MissingHooksForHookEventsErr            = "The AppSpec has no Hooks to generate events for"
+	WroteHookEventsMsg                      = "\nWrote %d hook events. Run them with: %s\n"
+
+	//
+	// Mock CodeDeploy
+	//
+
+	MockCodeDeployFailedErr          = "Mock deployment %s failed"
+	UninvokableMockCodeDeployHookErr = "%v is a placeholder or not a Lambda function name or ARN, so it can not be invoked for hook: %v"
+	MockCodeDeployInvokeFailedErr    = "\nERROR CAUSE: The invoke command failed before the hook reported a status:"
+	MockCodeDeployHookTimeoutErr     = "\nERROR CAUSE: The hook did not call PutLifecycleEventHookExecutionStatus in time:"
+
+	MockCodeDeployEndpointMsg      = "Mock CodeDeploy listening on %s, hook functions reach it at %s (AWS_ENDPOINT_URL_CODEDEPLOY and the {envVars} file)\n"
+	MockCodeDeployInvokingHookMsg  = "\n==> Invoking %s hook: %s\n"
+	MockCodeDeployResultsHeaderMsg = "\nHook results of mock deployment %s:\n"
+	MockCodeDeploySucceededMsg     = "\nMock deployment %s succeeded, all hooks reported Succeeded\n"
+
+	//
+	// Revisions (EC2/On-Prem)
+	//
+
+	UnsupportedRevisionFormatErr      = "The revision %s must be a directory or a .zip, .tgz, .tar.gz, or .tar archive"
+	MissingRevisionAppSpecErr         = "No appspec.yml or appspec.json in the revision %s"
+	MissingRevisionFileErr            = "%v does not exist in the revision %s"
+	MissingRevisionFileSourceErr      = "files -> source %v does not exist in the revision"
+	RevisionCopySymlinkOutsideErr     = "Not copying %v, it is a symlink to %v, which is outside the revision"
+	RevisionCopyThroughSymlinkErr     = "Not writing %v, %v is a symlink from the revision and could point anywhere"
+	RevisionCopyOutsideDestinationErr = "Not writing %v, it is not below %v"
+
+	MissingServerFileSourceInRevisionErr = "\nERROR CAUSE: files -> source does not exist in the revision (relative to the AppSpec root):"
+	MissingServerHookScriptInRevisionErr = "\nERROR CAUSE: The script location does not exist in the revision (relative to the AppSpec root): %v for hook: %v\n"
+	InvalidServerHookScriptTypeErr       = "\nERROR CAUSE: The script location must be a regular file, found a %v: %v for hook: %v\n"
+	CaseMismatchedRevisionPathErr        = "\nERROR CAUSE: %v only matches %v in the revision when ignoring case. Paths on Linux instances are case-sensitive\n"
+	CaseMismatchedRevisionPathWarn       = "WARNING: %v only matches %v in the revision when ignoring case. This only works because Windows paths are not case-sensitive\n"
+
+	// Hook script lint
+	MissingHookScriptShebangErr        = "\nERROR CAUSE: The script does not start with a shebang (#!/bin/bash), so Linux instances cannot run it: %v\n"
+	CrlfHookScriptErr                  = "\nERROR CAUSE: The script has Windows (CRLF) line endings, which break shell scripts on Linux instances: %v\n"
+	UnsupportedHookScriptTypeErr       = "\nERROR CAUSE: %v scripts cannot run on %v instances: %v\n"
+	NotExecutableHookScriptWarn        = "WARNING: The script is not executable in the revision, set its executable bit before bundling: %v\n"
+	InteractiveHookScriptWarn          = "WARNING: Line %d waits for input (%v), which hangs the deployment until the script times out: %v\n"
+	HookScriptNeverFailsWarn           = "WARNING: The script always exits with 0, so CodeDeploy never sees a failed command. Use set -e or exit non-zero on errors: %v\n"
+	PowerShellHookScriptNeverFailsWarn = "WARNING: The script always exits with 0, so CodeDeploy never sees a failed command. Use $ErrorActionPreference = 'Stop' or exit non-zero on errors: %v\n"
+
+	//
+	// Inspect
+	//
+
+	InvalidRevisionBundleErr            = "The revision bundle is invalid"
+	UndetectedAppSpecComputePlatformErr = "Could not tell the compute platform of the AppSpec, set --computePlatform"
+	WrappedRevisionAppSpecErr           = "\nERROR CAUSE: The AppSpec must be at the root of the revision, not in a folder. Archive the content of the folder instead of the folder. Found:"
+	MultipleRevisionAppSpecsWarn        = "WARNING: The revision has more than one AppSpec, only %v is used: %v\n"
+	MisnamedRevisionAppSpecWarn         = "WARNING: CodeDeploy only reads an AppSpec named appspec.yml or appspec.json, found:"
+	RevisionSymlinkWarn                 = "WARNING: %v is a symlink to %v. It is deployed as a link, so its target must exist on the instance\n"
+	RevisionSymlinkOutsideRevisionErr   = "\nERROR CAUSE: %v is a symlink to %v, which is outside the revision\n"
+
+	InspectRevisionSummaryMsg = "Revision %s: %d files, AppSpec %v (%v)\n"
+	InspectRevisionPassedMsg  = "\nRevision bundle has passed available inspection checks"
+
+	//
+	// Bundle
+	//
+
+	UnsupportedBundleFormatErr = "The bundle %s must be a .zip, .tgz, or .tar.gz file"
+	InvalidBundleAppSpecErr    = "\nERROR: The AppSpec is invalid, the bundle was not written"
+	MissingBundleFileErr       = "%v is referenced by the AppSpec but does not exist next to it"
+
+	WroteBundleMsg = "\nWrote %s with %d entries\nMD5:  %s\nETag: \"%s\" (S3 single part upload)\n"
+
+	//
+	// Simulate (EC2/On-Prem)
+	//
+
+	UnpackedSimulateServerRevisionErr   = "The revision %s must be an unpacked directory to run its scripts"
+	UnsupportedSimulateServerOSErr      = "The AppSpec os is %v, its scripts can only be simulated on a %v machine"
+	SimulateServerFailedErr             = "Simulated deployment %s failed"
+	UnconfirmedSimulateServerScriptsErr = "The hook scripts run directly on this machine as the current user, only the files are put in the sandbox. Review the scripts and pass --run-scripts-on-host to run them"
+
+	SimulateServerScriptFailedErr  = "\nERROR CAUSE: The %s script %v failed: %v\n"
+	SimulateServerScriptTimeoutErr = "\nERROR CAUSE: The %s script %v did not finish within its timeout of %d seconds\n"
+
+	SimulateServerApplicationStopWarn     = "WARNING: CodeDeploy runs the ApplicationStop scripts of the previous successful revision, and none on the first deployment to an instance"
+	SimulateServerRunasWarn               = "WARNING: runas %v is not applied, %v runs as the current user\n"
+	SimulateServerNotExecutableScriptWarn = "WARNING: %v is not executable, the CodeDeploy agent makes it executable before running it, so it is run with %v without changing the revision\n"
+	SimulateServerPermissionNotSetWarn    = "WARNING: Only mode is applied in the sandbox, not owner, group, acls, or context, for permission object: %v\n"
+	SimulateServerInvalidModeWarn         = "WARNING: mode %v is not an octal mode and is not applied, for permission object: %v\n"
+	SimulateServerWindowsPermissionWarn   = "WARNING: permissions only apply to Linux instances and are not applied"
+
+	SimulateServerSandboxMsg        = "Sandbox root (instance paths are put below it, SANDBOX_ROOT for the scripts): %s\nWARNING: The hook scripts are not sandboxed, they run on this machine as the current user and can change anything it can\n"
+	SimulateServerLifecycleEventMsg = "\n==> %s\n"
+	SimulateServerReservedEventMsg  = "Run by CodeDeploy, nothing to simulate"
+	SimulateServerNoLoadBalancerMsg = "Skipped, only runs in deployments with a load balancer (--load-balancer)"
+	SimulateServerInstallMsg        = "Copied %d files into the sandbox and applied %d modes\n"
+	SimulateServerRunningScriptMsg  = "Running %v (timeout %d seconds)\n"
+	SimulateServerResultsHeaderMsg  = "\nScript results of simulated deployment %s:\n"
+	SimulateServerSucceededMsg      = "\nSimulated deployment %s succeeded, all scripts exited with 0\n"
+
+	//
+	// Plan files (EC2/On-Prem)
+	//
+
+	DuplicateServerFileDestinationWarn = "WARNING: More than one file is copied to %v, from: %v\n"
+
+	PlanServerFilesSourceMsg = "files[%d] %v -> %v: %v, %d files\n"
+	PlanServerFilesTreeMsg   = "\nOn-instance file tree (%d files):\n"
+	StagedServerFilesMsg     = "\nStaged %d files in %s\n"
+
+	//
+	// Graph
+	//
+
+	UnsupportedGraphFormatErr   = "--format must be one of %v"
+	NotInLifecycleGraphHookWarn = "WARNING: %v is not a lifecycle event of %v deployments and is left out of the graph\n"
+	WroteLifecycleGraphMsg      = "\nWrote the %v graph to %s\n"
+
+	//
+	// Permissions report (EC2/On-Prem)
+	//
+
+	UnappliedServerPermissionWarn = "WARNING: permissions[%d] (object %v) does not apply to any deployed file or directory\n"
+
+	ServerPermissionsReportHeaderMsg = "\nEffective permissions of %d deployed files and directories (root:root and the modes of the revision unless a permissions entry sets them):\n"
+
+	//
+	// Timeline (EC2/On-Prem)
+	//
+
+	DefaultServerScriptTimeoutWarn = "WARNING: %v (%v) has no timeout and can run for the default of %d seconds\n"
+
+	ServerTimelineHeaderMsg    = "\nTimeline of %v:\n"
+	ServerTimelineInstancesMsg = "\n%v:\n"
+	ServerTimelineWorstCaseMsg = "  Worst case: %d seconds (%v) before CodeDeploy fails the deployment on an instance\n"
+
 	//
 	// ECS
 	//
@@ -27,12 +204,23 @@ const (
 	InvalidECSTargetServiceTypeErr     = "\nERROR CAUSE: TargetService Type must be AWS::ECS::Service"
 	InvalidECSTargetServicePropsErr    = "ERROR: Invalid TargetService properties"
 
-	EmptyECSTaskDefErr                = "\nERROR CAUSE: Resources -> TargetService -> Properties -> TaskDefinition must not be empty (ECS TaskDefinition)"
-	InvalidECSLoadBalancerInfoErr     = "ERROR: Resources -> TargetService -> Properties -> LoadBalancerInfo invalid"
-	InvalidECSNetworkConfigurationErr = "ERROR: Resources -> TargetService -> Properties -> NetworkConfiguration invalid"
+	EmptyECSTaskDefErr                    = "\nERROR CAUSE: Resources -> TargetService -> Properties -> TaskDefinition must not be empty (ECS TaskDefinition)"
+	InvalidECSLoadBalancerInfoErr         = "ERROR: Resources -> TargetService -> Properties -> LoadBalancerInfo invalid"
+	InvalidECSNetworkConfigurationErr     = "ERROR: Resources -> TargetService -> Properties -> NetworkConfiguration invalid"
+	InvalidECSCapacityProviderStrategyErr = "ERROR: Resources -> TargetService -> Properties -> CapacityProviderStrategy invalid"
+
+	InvalidECSTaskDefFormatErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> TaskDefinition must be a task definition ARN (arn:aws:ecs:region:account-id:task-definition/family:revision) or family:revision. Found:"
 
 	MissingECSContainerNameErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> LoadBalancerInfo ... ContainerName missing for:"
-	ZeroECSContainerPortWarn   = "WARNING: Resources -> TargetService -> Properties -> LoadBalancerInfo ... ContainerPort is 0. Please check this was on purpose for:"
+	InvalidECSContainerPortErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> LoadBalancerInfo ... ContainerPort must be between 1 and 65535 for:"
+
+	InvalidECSPlatformVersionErr  = "\nERROR CAUSE: Resources -> TargetService -> Properties -> PlatformVersion must be one of the Fargate platform versions %v. Found: %v for: %v\n"
+	IgnoredECSPlatformVersionWarn = "WARNING: Resources -> TargetService -> Properties -> PlatformVersion only applies to the Fargate launch type and is ignored for the EC2 launch type for:"
+
+	MissingECSCapacityProviderErr       = "\nERROR CAUSE: Resources -> TargetService -> Properties -> CapacityProviderStrategy ... CapacityProvider missing for:"
+	InvalidECSCapacityProviderBaseErr   = "\nERROR CAUSE: Resources -> TargetService -> Properties -> CapacityProviderStrategy ... Base must be between 0 and %d for capacity provider %v for: %v\n"
+	InvalidECSCapacityProviderWeightErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> CapacityProviderStrategy ... Weight must be between 0 and %d for capacity provider %v for: %v\n"
+	MultipleECSCapacityProviderBasesErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> CapacityProviderStrategy ... Only one capacity provider can have a Base for:"
 
 	MissingECSSubnetsErr         = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... Subnets missing for:"
 	EmptyECSSubnetStrsErr        = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... Subnets cannot be empty strings for:"
@@ -40,6 +228,21 @@ const (
 	EmptyECSSecurityGroupStrsErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... SecurityGroups cannot be empty strings for:"
 	MissingECSAssignPublicIpErr  = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... AssignPublicIp missing for:"
 	InvalidECSAssignPublicIpErr  = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... AssignPublicIp invalid (should be ENABLED or DISABLED) for:"
+	InvalidECSSubnetIdErr        = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... Subnets must be subnet IDs (subnet-0123456789abcdef0). Found: %v for: %v\n"
+	InvalidECSSecurityGroupIdErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... SecurityGroups must be security group IDs (sg-0123456789abcdef0). Found: %v for: %v\n"
+	TooManyECSAwsvpcIdsErr       = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... %v allows at most %d IDs, found %d for: %v\n"
+	DuplicateECSAwsvpcIdsErr     = "\nERROR CAUSE: Resources -> TargetService -> Properties -> AwsvpcConfiguration ... %v contains duplicate IDs %v for: %v\n"
+
+	// Task definition cross-checks
+	InvalidEcsTaskDefinitionFileErr            = "Task definition file %s is invalid: %v"
+	InvalidEcsPipelineTaskDefErr               = "\nERROR CAUSE: Resources -> TargetService -> Properties -> TaskDefinition must be <TASK_DEFINITION> for CodePipeline (--pipeline-mode). Found:"
+	MissingEcsPipelineImagePlaceholderWarn     = "\nWARNING: No container image in the task definition uses an <IMAGE1_NAME> style placeholder, so CodePipeline will not update any image:"
+	MissingEcsTaskDefinitionContainerErr       = "\nERROR CAUSE: Resources -> TargetService -> Properties -> LoadBalancerInfo ... ContainerName is not a container of the task definition. Containers: %v for: %v\n"
+	MissingEcsTaskDefinitionContainerPortErr   = "\nERROR CAUSE: Resources -> TargetService -> Properties -> LoadBalancerInfo ... ContainerPort is not a containerPort of the container in the task definition. Ports: %v for: %v\n"
+	InvalidEcsTaskDefinitionNetworkModeErr     = "\nERROR CAUSE: Resources -> TargetService -> Properties -> NetworkConfiguration requires the task definition networkMode to be awsvpc. Found: %v for: %v\n"
+	InvalidEcsTaskDefinitionCompatibilitiesErr = "\nERROR CAUSE: Resources -> TargetService -> Properties -> PlatformVersion only applies to Fargate, but the task definition requiresCompatibilities is %v for: %v\n"
+	MismatchedEcsTaskDefinitionFamilyErr       = "\nERROR CAUSE: Resources -> TargetService -> Properties -> TaskDefinition family does not match the task definition file family %v for: %v\n"
+	MismatchedEcsTaskDefinitionRevisionErr     = "\nERROR CAUSE: Resources -> TargetService -> Properties -> TaskDefinition revision does not match the task definition file revision %v for: %v\n"
 
 	EmptyEcsHookValErr   = "\nERROR CAUSE: Value cannot be empty for hook:"
 	InvalidEcsHookStrErr = "\nERROR CAUSE: The hooks must be one of the ECS supported hooks:"
@@ -63,8 +266,27 @@ const (
 	EmptyLambdaFunctionCurrVersionErr   = "\nERROR CAUSE: Resources -> <Function> -> Properties -> CurrentVersion must not be empty (Lambda Function current version, ex: 1) :"
 	EmptyLambdaFunctionTargetVersionErr = "\nERROR CAUSE: Resources -> <Function> -> Properties -> TargetVersion must not be empty (Lambda Function target version to flip to, ex: 2) :"
 
+	LongLambdaFunctionNameErr       = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Name must be at most %d characters (for ARNs, the function name part) for: %v\n"
+	InvalidLambdaFunctionNameErr    = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Name must be a function name (letters, numbers, hyphens, underscores) or function ARN (arn:aws:lambda:region:account-id:function:name). Found: %v for: %v\n"
+	NumericLambdaFunctionAliasErr   = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Alias %v looks like a version number. Alias names cannot be only digits, for: %v\n"
+	InvalidLambdaFunctionAliasErr   = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Alias must be 1 to %d letters, numbers, hyphens, or underscores. Found: %v for: %v\n"
+	InvalidLambdaFunctionVersionErr = "\nERROR CAUSE: Resources -> <Function> -> Properties -> %v must be a published version number (ex: 1), not $LATEST or an alias. Found: %v for: %v\n"
+	SameLambdaFunctionVersionsErr   = "\nERROR CAUSE: Resources -> <Function> -> Properties -> CurrentVersion and TargetVersion are both %v, there is no traffic to shift, for: %v\n"
+
+	MismatchedLambdaResourceNameWarn = "WARNING: Resources -> %v does not match its Properties -> Name %v. CodeDeploy deploys the function in Name\n"
+
 	EmptyLambdaHookValErr = "\nERROR CAUSE: Value cannot be empty for hook:"
 
+	// Inventory cross-checks
+	InvalidLambdaInventoryFileErr = "Lambda inventory file %s is invalid, it must be the output of list-versions-by-function or list-aliases: %v"
+	EmptyLambdaInventoryErr       = "no Versions or Aliases found"
+
+	MissingLambdaInventoryFunctionErr = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Name is not a function in the Lambda inventory. Functions: %v for: %v\n"
+	MissingLambdaInventoryAliasErr    = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Alias does not exist for the function in the Lambda inventory. Aliases: %v for: %v\n"
+	MismatchedLambdaAliasVersionErr   = "\nERROR CAUSE: Resources -> <Function> -> Properties -> Alias %v points at version %v, not CurrentVersion %v. A previous deployment may have already shifted it, for: %v\n"
+	UnpublishedLambdaTargetVersionErr = "\nERROR CAUSE: Resources -> <Function> -> Properties -> TargetVersion %v is not a published version of the function. Versions: %v for: %v\n"
+	LambdaAliasRoutingConfigWarn      = "WARNING: Resources -> <Function> -> Properties -> Alias %v still routes traffic to other versions %v, for: %v\n"
+
 	//
 	// Server (EC2/On-Prem)
 	//
@@ -83,10 +305,31 @@ const (
 	EmptyServerPermissionObjErr    = "\nERROR CAUSE: Object cannot be empty for permission:"
 	InvalidServerPermissionTypeErr = "\nERROR CAUSE: If Permission Type is specified, it must be `file` or `direcory` for permission:"
 
+	InvalidServerPermissionModeErr          = "\nERROR CAUSE: mode must be 3 or 4 octal digits, like 644 or 4755. Found: %v for permission object: %v\n"
+	InvalidServerPermissionAclErr           = "\nERROR CAUSE: acls entries must look like u:user:rwx, g:group:rw, m::r, or o::r, with d: in front for default ACLs. Found: %v for permission object: %v\n"
+	InvalidServerPermissionContextErr       = "\nERROR CAUSE: context %v must be %v. Found: %v for permission object: %v\n"
+	MissingServerPermissionContextTypeErr   = "\nERROR CAUSE: context must have a type when user or range is set, for permission object: %v\n"
+	InvalidServerPermissionGlobErr          = "\nERROR CAUSE: %v must be a valid glob pattern. Found: %v for permission object: %v\n"
+	ExceptWithoutPatternServerPermissionErr = "\nERROR CAUSE: except only applies to the files matched by pattern, so it needs a pattern, for permission object: %v\n"
+	InvalidServerPermissionAccountErr       = "\nERROR CAUSE: %v must be a Linux account name (letters, digits, _, ., or -, up to 32 characters) or a numeric ID. Found: %v for permission object: %v\n"
+	WindowsServerPermissionsErr             = "\nERROR CAUSE: permissions only apply to Linux instances, CodeDeploy ignores them with os: windows. Remove them or set the ACLs in a hook script"
+
+	UncoveredServerPermissionObjectWarn = "WARNING: permissions object %v is not at, below, or above any files destination. Permissions only apply to the files CodeDeploy copies at Install, so this one does nothing\n"
+
 	UnsupportedServerHooksErr        = "\nERROR CAUSE: The hooks must be one of the Ec2/OnPrem supported hooks."
 	SupportedServerHooksWithoutLBStr = "\nDeployments without a LoadBalancer: %v"
 	SupportedServerHooksWithLBStr    = "\nDeployments with a LoadBalancer: %v"
 
+	ReservedServerHookErr = "\nERROR CAUSE: %v is run by CodeDeploy and cannot have scripts. Reserved lifecycle events: %v\n"
+
+	// Deployment type
+	InvalidServerDeploymentTypeErr = "--deployment-type must be one of %v"
+	InvalidDeploymentGroupFileErr  = "Deployment group file %s is invalid, it must be the output of get-deployment-group: %v"
+
+	ServerDeploymentHooksHeaderMsg = "\nHooks that run in %v:\n"
+	ServerDeploymentHookTargetMsg  = "  %v: %v\n"
+	NeverRunningServerHookWarn     = "WARNING: %v never runs in %v\n"
+
 	MissingServerHookScriptLocationErr = "\nERROR CAUSE: The hook must have a script location:"
 	InvalidServerScriptTimeoutErr      = "\nERROR CAUSE: Total timeout for all scripts within a single LifecycleEvent added up must not exceed 3600 seconds. :"
 )
@@ -9,4 +9,60 @@ var AppSpecSupportedLambdaHooks = [...]string{"BeforeAllowTraffic", "AfterAllowT
 var AppSpecSupportedServerHooksWithLB = [...]string{"BeforeBlockTraffic", "AfterBlockTraffic", "BeforeAllowTraffic", "AfterAllowTraffic"}
 var AppSpecSupportedServerHooksWithoutLB = [...]string{"ApplicationStop", "BeforeInstall", "AfterInstall", "ApplicationStart", "ValidateService"}
 
+// The AWSCodeDeployRoleForLambda and AWSCodeDeployRoleForECS managed policies only allow invoking hook functions with this prefix
+var HookFunctionNamePrefix = "CodeDeployHook_"
+
+// Lifecycle events of ECS and Lambda deployments in order, including the ones CodeDeploy runs itself
+var AppSpecEcsLifecycleEvents = [...]string{"Start", "BeforeInstall", "Install", "AfterInstall", "AllowTestTraffic", "AfterAllowTestTraffic", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic", "End"}
+var AppSpecLambdaLifecycleEvents = [...]string{"Start", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic", "End"}
+
+// Output formats of the graph command
+var AppSpecGraphFormats = [...]string{"mermaid", "dot"}
+
+// Lifecycle events of an EC2/On-Prem in-place deployment in the order the CodeDeploy agent runs them
+// The reserved events are run by CodeDeploy and cannot have scripts
+var AppSpecServerLifecycleEvents = [...]string{"BeforeBlockTraffic", "BlockTraffic", "AfterBlockTraffic", "ApplicationStop", "DownloadBundle", "BeforeInstall", "Install", "AfterInstall", "ApplicationStart", "ValidateService", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic"}
+var AppSpecServerReservedLifecycleEvents = [...]string{"Start", "BlockTraffic", "DownloadBundle", "Install", "AllowTraffic", "End"}
+
+// Hooks by the instances they run on in a blue/green deployment, which always uses a load balancer
+var AppSpecServerBlueGreenOriginalHooks = [...]string{"BeforeBlockTraffic", "AfterBlockTraffic"}
+var AppSpecServerBlueGreenReplacementHooks = [...]string{"ApplicationStop", "BeforeInstall", "AfterInstall", "ApplicationStart", "ValidateService", "BeforeAllowTraffic", "AfterAllowTraffic"}
+var AppSpecServerDeploymentTypes = [...]string{"in-place", "blue-green"}
+
+// Seconds the CodeDeploy agent lets a hook script run when it has no timeout
+var ServerDefaultScriptTimeout = 3600
+
 var AppSpecEcsAssignPublicIpValues = [...]string{"ENABLED", "DISABLED"}
+var AppSpecEcsPlatformVersions = [...]string{"LATEST", "1.4.0", "1.3.0"}
+var AppSpecEcsFargateCapacityProviders = [...]string{"FARGATE", "FARGATE_SPOT"}
+
+// Values from the AppSpec templates in this repo that have to be replaced before deploying
+var AppSpecTemplatePlaceholderValues = [...]string{
+	"source-file-location", "destination-file-location",
+	"object-specification", "pattern-specification", "exception-specification", "owner-account-name", "group-name",
+	"mode-specification", "acls-specification", "user-specification", "type-specification", "range-specification", "object-type",
+	"script-location", "user-name",
+	"ENABLED-or-DISABLED", "SubnetId1", "SubnetId2", "ecs-security-group-1",
+	"BeforeInstallHookLambdaFunctionName", "AfterInstallHookLambdaFunctionName", "AfterAllowTestTrafficHookLambdaFunctionName",
+	"SanityTestHookLambdaFunctionName", "ValidationTestHookLambdaFunctionName",
+}
+
+// CodePipeline CodeDeployToECS action
+var EcsPipelineTaskDefinitionPlaceholder = "<TASK_DEFINITION>"
+var EcsPipelineTaskDefinitionFileName = "taskdef.json"
+
+// AWS partitions and the prefix of the regions in them (every other region is in the aws partition)
+var AwsPartitions = [...]string{"aws", "aws-cn", "aws-us-gov"}
+var AwsPartitionRegionPrefixes = map[string]string{"aws-cn": "cn-", "aws-us-gov": "us-gov-"}
+
+// ECS service limits for the AwsvpcConfiguration
+var EcsMaxSubnets = 16
+var EcsMaxSecurityGroups = 5
+
+// ECS service limits for the CapacityProviderStrategy
+var EcsMaxCapacityProviderBase = 100000
+var EcsMaxCapacityProviderWeight = 1000
+
+// Lambda naming limits
+var LambdaMaxFunctionNameLength = 64
+var LambdaMaxAliasLength = 128
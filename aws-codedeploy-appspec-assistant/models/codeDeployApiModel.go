@@ -0,0 +1,34 @@
+package models
+
+// Requests and responses of the CodeDeploy API actions the mock-codedeploy command serves
+
+type PutLifecycleEventHookExecutionStatusInput struct {
+	DeploymentId                  string `json:"deploymentId"`
+	LifecycleEventHookExecutionId string `json:"lifecycleEventHookExecutionId"`
+	Status                        string `json:"status"`
+}
+
+type PutLifecycleEventHookExecutionStatusOutput struct {
+	LifecycleEventHookExecutionId string `json:"lifecycleEventHookExecutionId"`
+}
+
+type GetDeploymentInput struct {
+	DeploymentId string `json:"deploymentId"`
+}
+
+type GetDeploymentOutput struct {
+	DeploymentInfo DeploymentInfo `json:"deploymentInfo"`
+}
+
+type DeploymentInfo struct {
+	DeploymentId        string `json:"deploymentId"`
+	ApplicationName     string `json:"applicationName"`
+	DeploymentGroupName string `json:"deploymentGroupName"`
+	Status              string `json:"status"`
+	ComputePlatform     string `json:"computePlatform"`
+}
+
+type CodeDeployErrorOutput struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Saved output of aws deploy get-deployment-group
+// Only the deployment style is used
+type DeploymentGroupModel struct {
+	DeploymentGroupInfo DeploymentGroupInfo `json:"deploymentGroupInfo" yaml:"deploymentGroupInfo"`
+}
+
+type DeploymentGroupInfo struct {
+	DeploymentGroupName string          `json:"deploymentGroupName" yaml:"deploymentGroupName"`
+	ComputePlatform     string          `json:"computePlatform" yaml:"computePlatform"`
+	DeploymentStyle     DeploymentStyle `json:"deploymentStyle" yaml:"deploymentStyle"`
+}
+
+type DeploymentStyle struct {
+	DeploymentType   string `json:"deploymentType" yaml:"deploymentType"`     // IN_PLACE or BLUE_GREEN
+	DeploymentOption string `json:"deploymentOption" yaml:"deploymentOption"` // WITH_TRAFFIC_CONTROL or WITHOUT_TRAFFIC_CONTROL
+}
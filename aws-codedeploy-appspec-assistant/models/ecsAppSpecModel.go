@@ -22,8 +22,9 @@ type EcsProperties struct {
 	LoadBalancerInfo LoadBalancerInfo `json:"LoadBalancerInfo" yaml:"LoadBalancerInfo"`
 
 	// Optional
-	PlatformVersion      string               `json:"PlatformVersion" yaml:"PlatformVersion"`
-	NetworkConfiguration NetworkConfiguration `json:"NetworkConfiguration" yaml:"NetworkConfiguration"`
+	PlatformVersion          string                         `json:"PlatformVersion" yaml:"PlatformVersion"`
+	NetworkConfiguration     NetworkConfiguration           `json:"NetworkConfiguration" yaml:"NetworkConfiguration"`
+	CapacityProviderStrategy []CapacityProviderStrategyItem `json:"CapacityProviderStrategy" yaml:"CapacityProviderStrategy"`
 }
 
 type LoadBalancerInfo struct {
@@ -40,3 +41,11 @@ type AwsvpcConfiguration struct {
 	SecurityGroups []string `json:"SecurityGroups" yaml:"SecurityGroups"`
 	AssignPublicIp string   `json:"AssignPublicIp" yaml:"AssignPublicIp"`
 }
+
+type CapacityProviderStrategyItem struct {
+	CapacityProvider string `json:"CapacityProvider" yaml:"CapacityProvider"`
+
+	// Optional
+	Base   int `json:"Base" yaml:"Base"`
+	Weight int `json:"Weight" yaml:"Weight"`
+}
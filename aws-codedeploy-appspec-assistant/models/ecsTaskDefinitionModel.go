@@ -0,0 +1,34 @@
+package models
+
+// Output of describe-task-definition
+type EcsDescribeTaskDefinitionModel struct {
+	TaskDefinition *EcsTaskDefinitionModel `json:"taskDefinition" yaml:"taskDefinition"`
+}
+
+// ECS task definition as registered (taskdef.json) or as returned by describe-task-definition
+type EcsTaskDefinitionModel struct {
+	ContainerDefinitions []ContainerDefinition `json:"containerDefinitions" yaml:"containerDefinitions"`
+
+	// Optional
+	TaskDefinitionArn       string   `json:"taskDefinitionArn" yaml:"taskDefinitionArn"`
+	Family                  string   `json:"family" yaml:"family"`
+	Revision                int      `json:"revision" yaml:"revision"`
+	NetworkMode             string   `json:"networkMode" yaml:"networkMode"`
+	RequiresCompatibilities []string `json:"requiresCompatibilities" yaml:"requiresCompatibilities"`
+}
+
+type ContainerDefinition struct {
+	Name  string `json:"name" yaml:"name"`
+	Image string `json:"image" yaml:"image"`
+
+	// Optional
+	PortMappings []PortMapping `json:"portMappings" yaml:"portMappings"`
+}
+
+type PortMapping struct {
+	ContainerPort int `json:"containerPort" yaml:"containerPort"`
+
+	// Optional
+	HostPort int    `json:"hostPort" yaml:"hostPort"`
+	Protocol string `json:"protocol" yaml:"protocol"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// Saved output of aws lambda list-versions-by-function and aws lambda list-aliases
+// A file holds one of the two lists
+type LambdaInventoryModel struct {
+	Versions []LambdaFunctionVersion `json:"Versions" yaml:"Versions"`
+	Aliases  []LambdaFunctionAlias   `json:"Aliases" yaml:"Aliases"`
+}
+
+type LambdaFunctionVersion struct {
+	FunctionName string `json:"FunctionName" yaml:"FunctionName"`
+	FunctionArn  string `json:"FunctionArn" yaml:"FunctionArn"`
+	Version      string `json:"Version" yaml:"Version"`
+}
+
+type LambdaFunctionAlias struct {
+	AliasArn        string `json:"AliasArn" yaml:"AliasArn"`
+	Name            string `json:"Name" yaml:"Name"`
+	FunctionVersion string `json:"FunctionVersion" yaml:"FunctionVersion"`
+
+	// Optional
+	RoutingConfig LambdaAliasRoutingConfig `json:"RoutingConfig" yaml:"RoutingConfig"`
+}
+
+type LambdaAliasRoutingConfig struct {
+	AdditionalVersionWeights map[string]float64 `json:"AdditionalVersionWeights" yaml:"AdditionalVersionWeights"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// Event CodeDeploy invokes an ECS or Lambda hook function with
+type LifecycleHookEventModel struct {
+	DeploymentId                  string `json:"DeploymentId"`
+	LifecycleEventHookExecutionId string `json:"LifecycleEventHookExecutionId"`
+}
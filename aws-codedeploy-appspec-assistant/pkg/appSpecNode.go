@@ -0,0 +1,136 @@
+package assistant
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+)
+
+// Convert AppSpec string to a YAML node tree
+// go-yaml V3 also reads JSON, so this deals with JSON and YAML
+// Working on the node tree keeps key order and comments intact
+func getAppSpecNodeFromString(appSpecBytes []byte) (*yaml.Node, error) {
+	var appSpecNode yaml.Node
+
+	if err := yaml.Unmarshal(appSpecBytes, &appSpecNode); err != nil {
+		return nil, err
+	}
+
+	if appSpecNode.Kind != yaml.DocumentNode || len(appSpecNode.Content) < 1 {
+		return nil, fmt.Errorf(errorHandling.EmptyAppSpecFileErr)
+	}
+
+	return &appSpecNode, nil
+}
+
+// Convert a YAML node tree back to an AppSpec string
+// Uses the saved fileExtension to decide between JSON and YAML
+func getStringFromAppSpecNode(appSpecNode *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if fileExtension == "yml" {
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(appSpecNode); err != nil {
+			return nil, err
+		}
+		encoder.Close()
+		return buf.Bytes(), nil
+	}
+
+	if err := encodeJsonNode(&buf, appSpecNode, 0); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+// Write a YAML node tree as indented JSON
+// encoding/json sorts map keys, so the tree is written by hand to keep the original key order
+func encodeJsonNode(buf *bytes.Buffer, node *yaml.Node, indent int) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return encodeJsonNode(buf, node.Content[0], indent)
+	case yaml.AliasNode:
+		return encodeJsonNode(buf, node.Alias, indent)
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			buf.WriteString(strings.Repeat("  ", indent+1))
+			writeJsonString(buf, node.Content[i].Value)
+			buf.WriteString(": ")
+			if err := encodeJsonNode(buf, node.Content[i+1], indent+1); err != nil {
+				return err
+			}
+			if i+2 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(strings.Repeat("  ", indent) + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, item := range node.Content {
+			buf.WriteString(strings.Repeat("  ", indent+1))
+			if err := encodeJsonNode(buf, item, indent+1); err != nil {
+				return err
+			}
+			if i+1 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(strings.Repeat("  ", indent) + "]")
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!int", "!!float", "!!bool":
+			buf.WriteString(node.Value)
+		case "!!null":
+			buf.WriteString("null")
+		default:
+			writeJsonString(buf, node.Value)
+		}
+	default:
+		return fmt.Errorf(errorHandling.UnsupportedAppSpecNodeErr, node.Kind)
+	}
+
+	return nil
+}
+
+func writeJsonString(buf *bytes.Buffer, value string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	// json.Encoder always ends with a newline
+	buf.Truncate(buf.Len() - 1)
+}
+
+// Get the value node for a key in a mapping node
+// Returns nil if the node is not a mapping or the key does not exist
+func getMappingValueNode(mappingNode *yaml.Node, key string) *yaml.Node {
+	if mappingNode == nil || mappingNode.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value == key {
+			return mappingNode.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package assistant
+
+import (
+	"testing"
+)
+
+// Test getStringFromAppSpecNode
+func TestGetStringFromAppSpecNode_Json(t *testing.T) {
+	var tests = []struct {
+		name           string
+		appSpecInput   string
+		expectedOutput string
+	}{
+		{"Key order and value types are kept",
+			`{"version": 0.0, "os": "linux", "files": [{"source": "/", "destination": "/var/www"}], "hooks": {}}`,
+			"{\n  \"version\": 0.0,\n  \"os\": \"linux\",\n  \"files\": [\n    {\n      \"source\": \"/\",\n      \"destination\": \"/var/www\"\n    }\n  ],\n  \"hooks\": {}\n}\n"},
+		{"Strings are escaped",
+			`{"a": "<b> \"c\"", "d": [1, true, null]}`,
+			"{\n  \"a\": \"<b> \\\"c\\\"\",\n  \"d\": [\n    1,\n    true,\n    null\n  ]\n}\n"},
+	}
+
+	for _, test := range tests {
+		fileExtension = "json"
+		appSpecNode, err := getAppSpecNodeFromString([]byte(test.appSpecInput))
+		if err != nil {
+			t.Errorf("getAppSpecNodeFromString FAILED for: %v", test.name)
+			continue
+		}
+		output, err := getStringFromAppSpecNode(appSpecNode)
+		if err != nil || string(output) != test.expectedOutput {
+			t.Errorf("The getStringFromAppSpecNode function did not write the expected JSON for: %v. Got: %v", test.name, string(output))
+		}
+	}
+}
+
+func TestGetAppSpecNodeFromString_InvalidInput(t *testing.T) {
+	var tests = []struct {
+		name         string
+		appSpecInput string
+	}{
+		{"Empty", ""},
+		{"Invalid YAML", "version: [0.0"},
+	}
+
+	for _, test := range tests {
+		if _, err := getAppSpecNodeFromString([]byte(test.appSpecInput)); err == nil {
+			t.Errorf("The getAppSpecNodeFromString function did not fail for: %v", test.name)
+		}
+	}
+}
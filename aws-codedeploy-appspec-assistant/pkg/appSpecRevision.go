@@ -0,0 +1,398 @@
+package assistant
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+)
+
+// Names CodeDeploy looks for at the root of a revision
+var revisionAppSpecFileNames = []string{"appspec.yml", "appspec.json"}
+
+// A file, directory, or symlink of a revision
+type revisionEntry struct {
+	path       string // Relative to the revision root, slash separated
+	mode       os.FileMode
+	linkTarget string
+}
+
+// A revision directory or zip/tgz/tar archive
+// Archive content is read into memory, directories are read from disk
+type appSpecRevision struct {
+	path     string
+	isDir    bool
+	entries  map[string]revisionEntry
+	contents map[string][]byte
+	rootDir  string // Directory of the AppSpec, "" at the root of the revision
+}
+
+// Load the entries of a revision directory or archive
+func loadAppSpecRevision(revisionPath string) (*appSpecRevision, error) {
+	revisionInfo, err := os.Stat(revisionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &appSpecRevision{path: revisionPath, entries: map[string]revisionEntry{}, contents: map[string][]byte{}}
+	lowerRevisionPath := strings.ToLower(revisionPath)
+
+	switch {
+	case revisionInfo.IsDir():
+		revision.isDir = true
+		err = revision.loadDir()
+	case strings.HasSuffix(lowerRevisionPath, ".zip"):
+		err = revision.loadZip()
+	case strings.HasSuffix(lowerRevisionPath, ".tgz") || strings.HasSuffix(lowerRevisionPath, ".tar.gz"):
+		err = revision.loadTar(true)
+	case strings.HasSuffix(lowerRevisionPath, ".tar"):
+		err = revision.loadTar(false)
+	default:
+		err = fmt.Errorf(errorHandling.UnsupportedRevisionFormatErr, revisionPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	revision.rootDir = revision.getAppSpecRootDir()
+
+	return revision, nil
+}
+
+func (revision *appSpecRevision) loadDir() error {
+	// Walk does not follow symlinks, so they are entries of their own
+	return filepath.Walk(revision.path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(revision.path, filePath)
+		if err != nil || relativePath == "." {
+			return err
+		}
+
+		entry := revisionEntry{path: filepath.ToSlash(relativePath), mode: info.Mode()}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if entry.linkTarget, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+		}
+		revision.addEntry(entry)
+
+		return nil
+	})
+}
+
+func (revision *appSpecRevision) loadZip() error {
+	zipReader, err := zip.OpenReader(revision.path)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, zipFile := range zipReader.File {
+		entry := revisionEntry{path: zipFile.Name, mode: zipFile.Mode()}
+
+		if !entry.mode.IsDir() {
+			content, err := readZipFile(zipFile)
+			if err != nil {
+				return err
+			}
+			// The target of a symlink is its content
+			if entry.mode&os.ModeSymlink != 0 {
+				entry.linkTarget = string(content)
+			} else {
+				revision.contents[cleanRevisionPath(entry.path)] = content
+			}
+		}
+		revision.addEntry(entry)
+	}
+
+	return nil
+}
+
+func readZipFile(zipFile *zip.File) ([]byte, error) {
+	zipFileReader, err := zipFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer zipFileReader.Close()
+
+	return ioutil.ReadAll(zipFileReader)
+}
+
+func (revision *appSpecRevision) loadTar(isGzipped bool) error {
+	tarFile, err := os.Open(revision.path)
+	if err != nil {
+		return err
+	}
+	defer tarFile.Close()
+
+	var reader io.Reader = tarFile
+	if isGzipped {
+		gzipReader, err := gzip.NewReader(tarFile)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := revisionEntry{path: header.Name, mode: header.FileInfo().Mode(), linkTarget: header.Linkname}
+		if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeRegA {
+			content, err := ioutil.ReadAll(tarReader)
+			if err != nil {
+				return err
+			}
+			revision.contents[cleanRevisionPath(entry.path)] = content
+		}
+		revision.addEntry(entry)
+	}
+}
+
+// Add an entry and the parent directories archives do not always list
+func (revision *appSpecRevision) addEntry(entry revisionEntry) {
+	entry.path = cleanRevisionPath(entry.path)
+	if entry.path == "" {
+		return
+	}
+	revision.entries[entry.path] = entry
+
+	for dir := path.Dir(entry.path); dir != "."; dir = path.Dir(dir) {
+		if _, ok := revision.entries[dir]; !ok {
+			revision.entries[dir] = revisionEntry{path: dir, mode: os.ModeDir | 0755}
+		}
+	}
+}
+
+// Relative, slash separated, without ./ or trailing slashes
+func cleanRevisionPath(revisionPath string) string {
+	cleanPath := path.Clean("/" + strings.Replace(revisionPath, "\\", "/", -1))
+
+	return strings.TrimPrefix(cleanPath, "/")
+}
+
+// The AppSpec at the root of the revision, or else the one closest to the root
+func (revision *appSpecRevision) getAppSpecRootDir() string {
+	appSpecPaths := revision.getAppSpecPaths()
+	if len(appSpecPaths) < 1 {
+		return ""
+	}
+
+	return strings.TrimSuffix(path.Dir(appSpecPaths[0]), ".")
+}
+
+// The AppSpec files of the revision, closest to the root first
+func (revision *appSpecRevision) getAppSpecPaths() []string {
+	var appSpecPaths []string
+
+	for entryPath, entry := range revision.entries {
+		if !entry.mode.IsDir() && containsString(revisionAppSpecFileNames, path.Base(entryPath)) {
+			appSpecPaths = append(appSpecPaths, entryPath)
+		}
+	}
+
+	sort.Slice(appSpecPaths, func(i, j int) bool {
+		depthI, depthJ := strings.Count(appSpecPaths[i], "/"), strings.Count(appSpecPaths[j], "/")
+		if depthI != depthJ {
+			return depthI < depthJ
+		}
+		return appSpecPaths[i] < appSpecPaths[j]
+	})
+
+	return appSpecPaths
+}
+
+// The path of the AppSpec of the revision
+func (revision *appSpecRevision) getAppSpecPath() (string, error) {
+	for _, appSpecFileName := range revisionAppSpecFileNames {
+		appSpecPath := path.Join(revision.rootDir, appSpecFileName)
+		if entry, ok := revision.entries[appSpecPath]; ok && !entry.mode.IsDir() {
+			return appSpecPath, nil
+		}
+	}
+
+	return "", fmt.Errorf(errorHandling.MissingRevisionAppSpecErr, revision.path)
+}
+
+// Read the AppSpec of the revision and note its file extension for parsing
+func (revision *appSpecRevision) readAppSpec() ([]byte, error) {
+	appSpecPath, err := revision.getAppSpecPath()
+	if err != nil {
+		return nil, err
+	}
+
+	appSpecBytes, err := revision.readFile(appSpecPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(appSpecBytes) < 1 {
+		return nil, fmt.Errorf(errorHandling.EmptyAppSpecFileErr)
+	}
+
+	saveFileExtension(appSpecPath)
+
+	return appSpecBytes, nil
+}
+
+// Read a file by its path relative to the revision root
+func (revision *appSpecRevision) readFile(entryPath string) ([]byte, error) {
+	if revision.isDir {
+		return ioutil.ReadFile(filepath.Join(revision.path, filepath.FromSlash(entryPath)))
+	}
+
+	content, ok := revision.contents[cleanRevisionPath(entryPath)]
+	if !ok {
+		return nil, fmt.Errorf(errorHandling.MissingRevisionFileErr, entryPath, revision.path)
+	}
+
+	return content, nil
+}
+
+// The files and symlinks below the AppSpec root, relative to it and sorted
+func (revision *appSpecRevision) getFiles() []string {
+	var files []string
+
+	for entryPath, entry := range revision.entries {
+		if entry.mode.IsDir() {
+			continue
+		}
+		if relativePath, ok := revision.getRelativePath(entryPath); ok {
+			files = append(files, relativePath)
+		}
+	}
+	sort.Strings(files)
+
+	return files
+}
+
+// The destination must be below the root, and none of the paths between them can be a symlink
+// An existing symlink at the destination is only replaced by another symlink, never written through
+func checkCopyDestination(destinationRootPath string, destinationPath string, isSymlink bool) error {
+	relativePath, err := filepath.Rel(destinationRootPath, destinationPath)
+	if err != nil || relativePath == "." || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return fmt.Errorf(errorHandling.RevisionCopyOutsideDestinationErr, destinationPath, destinationRootPath)
+	}
+
+	checkPath := destinationRootPath
+	pathParts := strings.Split(relativePath, string(filepath.Separator))
+	for i, pathPart := range pathParts {
+		checkPath = filepath.Join(checkPath, pathPart)
+		if isSymlink && i == len(pathParts)-1 {
+			break
+		}
+
+		info, err := os.Lstat(checkPath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf(errorHandling.RevisionCopyThroughSymlinkErr, destinationPath, checkPath)
+		}
+	}
+
+	return nil
+}
+
+// Path relative to the AppSpec root, false if it is not below it
+func (revision *appSpecRevision) getRelativePath(entryPath string) (string, bool) {
+	if revision.rootDir == "" {
+		return entryPath, true
+	}
+	if !strings.HasPrefix(entryPath, revision.rootDir+"/") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(entryPath, revision.rootDir+"/"), true
+}
+
+// Look up a path relative to the AppSpec root
+func (revision *appSpecRevision) getEntry(relativePath string) (revisionEntry, bool) {
+	entry, ok := revision.entries[cleanRevisionPath(path.Join(revision.rootDir, cleanRevisionPath(relativePath)))]
+
+	return entry, ok
+}
+
+// Look up a path relative to the AppSpec root ignoring case
+// Returns the path as it is in the revision
+func (revision *appSpecRevision) findEntryIgnoringCase(relativePath string) (string, bool) {
+	lowerEntryPath := strings.ToLower(cleanRevisionPath(path.Join(revision.rootDir, cleanRevisionPath(relativePath))))
+
+	var foundPaths []string
+	for entryPath := range revision.entries {
+		if strings.ToLower(entryPath) == lowerEntryPath {
+			foundPaths = append(foundPaths, entryPath)
+		}
+	}
+	if len(foundPaths) < 1 {
+		return "", false
+	}
+
+	// The same path with different cases can be in a revision more than once
+	sort.Strings(foundPaths)
+	foundPath, _ := revision.getRelativePath(foundPaths[0])
+
+	return foundPath, true
+}
+
+// Copy a file of the revision to a local path below destinationRootPath, keeping its mode
+// Symlinks are copied as links, so nothing is written through a symlink below the root that could point outside it
+func (revision *appSpecRevision) copyFile(relativePath string, destinationRootPath string, destinationPath string) error {
+	entry, ok := revision.getEntry(relativePath)
+	if !ok {
+		return fmt.Errorf(errorHandling.MissingRevisionFileErr, relativePath, revision.path)
+	}
+
+	isSymlink := entry.mode&os.ModeSymlink != 0
+	if isSymlink && isRevisionSymlinkOutside(entry) {
+		return fmt.Errorf(errorHandling.RevisionCopySymlinkOutsideErr, relativePath, entry.linkTarget)
+	}
+
+	if err := checkCopyDestination(destinationRootPath, destinationPath, isSymlink); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destinationPath), 0755); err != nil {
+		return err
+	}
+
+	if isSymlink {
+		os.Remove(destinationPath)
+		return os.Symlink(entry.linkTarget, destinationPath)
+	}
+
+	content, err := revision.readFile(entry.path)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(destinationPath, content, entry.mode.Perm()); err != nil {
+		return err
+	}
+
+	// WriteFile does not change the mode of existing files and applies the umask
+	return os.Chmod(destinationPath, entry.mode.Perm())
+}
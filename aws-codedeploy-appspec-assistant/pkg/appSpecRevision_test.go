@@ -0,0 +1,252 @@
+package assistant
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+)
+
+var testRevisionFiles = map[string]string{
+	"appspec.yml":      "version: 0.0\nos: linux\nfiles:\n  - source: app\n    destination: /var/www\n",
+	"app/index.html":   "<html></html>",
+	"scripts/start.sh": "#!/bin/sh\n",
+}
+
+func writeTestRevisionDir(t *testing.T, revisionFiles map[string]string) string {
+	revisionDirPath, err := ioutil.TempDir("", "revision")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for revisionFile, content := range revisionFiles {
+		os.MkdirAll(filepath.Dir(filepath.Join(revisionDirPath, revisionFile)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(revisionDirPath, revisionFile), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return revisionDirPath
+}
+
+func writeTestRevisionZip(t *testing.T, archivePath string, revisionFiles map[string]string) {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for revisionFile, content := range revisionFiles {
+		header := &zip.FileHeader{Name: revisionFile, Method: zip.Deflate}
+		header.SetMode(0755)
+		fileWriter, _ := zipWriter.CreateHeader(header)
+		fileWriter.Write([]byte(content))
+	}
+	zipWriter.Close()
+
+	if err := ioutil.WriteFile(archivePath, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestRevisionTgz(t *testing.T, archivePath string, revisionFiles map[string]string) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for revisionFile, content := range revisionFiles {
+		tarWriter.WriteHeader(&tar.Header{Name: "./" + revisionFile, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tarWriter.Write([]byte(content))
+	}
+	tarWriter.WriteHeader(&tar.Header{Name: "./scripts/link.sh", Linkname: "start.sh", Typeflag: tar.TypeSymlink, Mode: 0777})
+	tarWriter.Close()
+	gzipWriter.Close()
+
+	if err := ioutil.WriteFile(archivePath, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test loadAppSpecRevision loads the same entries from directories and archives
+func TestLoadAppSpecRevision(t *testing.T) {
+	revisionDirPath := writeTestRevisionDir(t, testRevisionFiles)
+	defer os.RemoveAll(revisionDirPath)
+
+	archiveDirPath, _ := ioutil.TempDir("", "archives")
+	defer os.RemoveAll(archiveDirPath)
+
+	zipPath := filepath.Join(archiveDirPath, "revision.zip")
+	writeTestRevisionZip(t, zipPath, testRevisionFiles)
+	tgzPath := filepath.Join(archiveDirPath, "revision.tgz")
+	writeTestRevisionTgz(t, tgzPath, testRevisionFiles)
+
+	var tests = []struct {
+		name          string
+		revisionPath  string
+		expectedFiles string
+	}{
+		{"Directory", revisionDirPath, "app/index.html,appspec.yml,scripts/start.sh"},
+		{"Zip", zipPath, "app/index.html,appspec.yml,scripts/start.sh"},
+		{"Tgz with symlink", tgzPath, "app/index.html,appspec.yml,scripts/link.sh,scripts/start.sh"},
+	}
+
+	for _, test := range tests {
+		revision, err := loadAppSpecRevision(test.revisionPath)
+		if err != nil {
+			t.Errorf("The loadAppSpecRevision function failed for: %v, %v", test.name, err)
+			continue
+		}
+
+		if files := strings.Join(revision.getFiles(), ","); files != test.expectedFiles {
+			t.Errorf("The loadAppSpecRevision function loaded %v instead of %v for: %v", files, test.expectedFiles, test.name)
+		}
+
+		if entry, ok := revision.getEntry("./app"); !ok || !entry.mode.IsDir() {
+			t.Errorf("The loadAppSpecRevision function did not add the app directory for: %v", test.name)
+		}
+
+		appSpecBytes, err := revision.readAppSpec()
+		if err != nil || string(appSpecBytes) != testRevisionFiles["appspec.yml"] || fileExtension != "yml" {
+			t.Errorf("The readAppSpec function returned %q, %v for: %v", appSpecBytes, err, test.name)
+		}
+	}
+
+	if _, err := loadAppSpecRevision(filepath.Join(revisionDirPath, "appspec.yml")); err == nil {
+		t.Errorf("The loadAppSpecRevision function succeeded for an unsupported revision format")
+	}
+}
+
+// Test the AppSpec root of a revision with a wrapping folder
+func TestAppSpecRevision_RootDir(t *testing.T) {
+	revisionDirPath := writeTestRevisionDir(t, map[string]string{
+		"bundle/appspec.json":       "{}",
+		"bundle/Scripts/start.sh":   "#!/bin/sh\n",
+		"bundle/nested/appspec.yml": "version: 0.0\n",
+	})
+	defer os.RemoveAll(revisionDirPath)
+
+	revision, err := loadAppSpecRevision(revisionDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if revision.rootDir != "bundle" {
+		t.Errorf("The AppSpec root of the revision is %v instead of bundle", revision.rootDir)
+	}
+
+	if appSpecPath, err := revision.getAppSpecPath(); err != nil || appSpecPath != "bundle/appspec.json" {
+		t.Errorf("The getAppSpecPath function returned %v, %v", appSpecPath, err)
+	}
+
+	if _, ok := revision.getEntry("scripts/start.sh"); ok {
+		t.Errorf("The getEntry function found a path that only matches when ignoring case")
+	}
+
+	if foundPath, ok := revision.findEntryIgnoringCase("scripts/start.sh"); !ok || foundPath != "Scripts/start.sh" {
+		t.Errorf("The findEntryIgnoringCase function returned %v, %v", foundPath, ok)
+	}
+}
+
+// Test copyFile keeps the mode of the revision file
+func TestAppSpecRevision_CopyFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("File modes are not kept on Windows")
+	}
+
+	archiveDirPath, _ := ioutil.TempDir("", "archives")
+	defer os.RemoveAll(archiveDirPath)
+
+	zipPath := filepath.Join(archiveDirPath, "revision.zip")
+	writeTestRevisionZip(t, zipPath, testRevisionFiles)
+
+	revision, err := loadAppSpecRevision(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	destinationPath := filepath.Join(archiveDirPath, "out", "start.sh")
+	if err := revision.copyFile("scripts/start.sh", archiveDirPath, destinationPath); err != nil {
+		t.Fatalf("The copyFile function failed: %v", err)
+	}
+
+	if info, err := os.Stat(destinationPath); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("The copyFile function did not keep the mode: %v, %v", info, err)
+	}
+
+	if err := revision.copyFile("scripts/missing.sh", archiveDirPath, destinationPath); err == nil {
+		t.Errorf("The copyFile function succeeded for a file that is not in the revision")
+	}
+}
+
+// Write a tar with a symlink directory and a file below it, in the order of the archive
+func writeTestRevisionSymlinkTar(t *testing.T, archivePath string, linkTarget string) {
+	var buffer bytes.Buffer
+	tarWriter := tar.NewWriter(&buffer)
+	appSpec := testRevisionFiles["appspec.yml"]
+	tarWriter.WriteHeader(&tar.Header{Name: "appspec.yml", Mode: 0644, Size: int64(len(appSpec)), Typeflag: tar.TypeReg})
+	tarWriter.Write([]byte(appSpec))
+	tarWriter.WriteHeader(&tar.Header{Name: "app/evil", Linkname: linkTarget, Typeflag: tar.TypeSymlink, Mode: 0777})
+	tarWriter.WriteHeader(&tar.Header{Name: "app/evil/x", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	tarWriter.Write([]byte("x"))
+	tarWriter.Close()
+
+	if err := ioutil.WriteFile(archivePath, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test copyFile never writes through a symlink of the revision, so files stay below the destination root
+func TestAppSpecRevision_CopyFileSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Creating symlinks needs privileges on Windows")
+	}
+
+	testDirPath, _ := ioutil.TempDir("", "symlinks")
+	defer os.RemoveAll(testDirPath)
+	escapeDirPath := filepath.Join(testDirPath, "escape")
+	os.MkdirAll(escapeDirPath, 0755)
+
+	var tests = []struct {
+		name       string
+		linkTarget string
+	}{
+		{"Absolute target", escapeDirPath},
+		{"Target outside the revision", "../../escape"},
+		{"Target inside the revision", "."},
+	}
+
+	for i, test := range tests {
+		archivePath := filepath.Join(testDirPath, fmt.Sprintf("revision%d.tar", i))
+		writeTestRevisionSymlinkTar(t, archivePath, test.linkTarget)
+
+		revision, err := loadAppSpecRevision(archivePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stagingDirPath := filepath.Join(testDirPath, fmt.Sprintf("stage%d", i))
+		failed := false
+		for _, file := range revision.getFiles() {
+			if err := revision.copyFile(file, stagingDirPath, filepath.Join(stagingDirPath, filepath.FromSlash(file))); err != nil {
+				failed = true
+			}
+		}
+
+		if !failed {
+			t.Errorf("The copyFile function copied every file for: %v", test.name)
+		}
+		if escaped, _ := ioutil.ReadDir(escapeDirPath); len(escaped) > 0 {
+			t.Errorf("The copyFile function wrote outside the destination root for: %v", test.name)
+		}
+		if _, err := os.Stat(filepath.Join(stagingDirPath, "app", "x")); err == nil {
+			t.Errorf("The copyFile function wrote through the symlink for: %v", test.name)
+		}
+	}
+
+	if err := checkCopyDestination(testDirPath, filepath.Join(testDirPath, "..", "x"), false); err == nil {
+		t.Errorf("The checkCopyDestination function succeeded for a destination outside the root")
+	}
+}
@@ -22,6 +22,27 @@ func ValidateAppSpec(filePath string, computePlatform string) {
 		errorHandling.HandleError(err)
 	}
 
+	if computePlatform == "ecs" {
+		if err := setupEcsTaskDefinition(filePath); err != nil {
+			errorHandling.HandleError(err)
+		}
+	}
+
+	if computePlatform == "lambda" {
+		if err := setupLambdaInventory(); err != nil {
+			errorHandling.HandleError(err)
+		}
+	}
+
+	if computePlatform == "server" {
+		if err := setupServerRevision(); err != nil {
+			errorHandling.HandleError(err)
+		}
+		if err := setupServerDeployment(); err != nil {
+			errorHandling.HandleError(err)
+		}
+	}
+
 	// Load AppSpec
 	raw_appSpec, err := ioutil.ReadFile(filePath)
 	if err != nil {
@@ -33,6 +54,20 @@ func ValidateAppSpec(filePath string, computePlatform string) {
 		errorHandling.HandleError(fmt.Errorf(errorHandling.EmptyAppSpecFileErr))
 	}
 
+	if FixAppSpec || PreviewAppSpecFixes {
+		raw_appSpec, err = applyAppSpecFixes(filePath, raw_appSpec, computePlatform)
+		if err != nil {
+			errorHandling.HandleError(err)
+		}
+	}
+
+	if SubstituteAppSpecVariables || AppSpecValuesFilePath != "" {
+		raw_appSpec, err = applyAppSpecVariables(raw_appSpec)
+		if err != nil {
+			errorHandling.HandleError(err)
+		}
+	}
+
 	if validationErr := runValidation(raw_appSpec, computePlatform); validationErr != nil {
 		errorHandling.HandleError(validationErr)
 	}
@@ -68,6 +103,25 @@ func validateUserInput(filePath string, computePlatform string) error {
 	return nil
 }
 
+// Validate the user input and load the AppSpec file
+// Used by the commands that read AppSpec files besides validate
+func loadAppSpecFile(filePath string, computePlatform string) ([]byte, error) {
+	if err := validateUserInput(filePath, computePlatform); err != nil {
+		return nil, err
+	}
+
+	appSpecBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(appSpecBytes) < 1 {
+		return nil, fmt.Errorf(errorHandling.EmptyAppSpecFileErr)
+	}
+
+	return appSpecBytes, nil
+}
+
 func isValidFileNameAndExtension(filePath string) bool {
 	if strings.HasSuffix(filePath, "appspec.json") || strings.HasSuffix(filePath, "appspec.yml") {
 		return true
@@ -123,6 +177,11 @@ func runValidation(appSpec []byte, computePlatform string) error {
 		err = validateServerAppSpec(serverAppSpecModel)
 	}
 
+	// Placeholders are valid strings for the checks above
+	if !validateAppSpecPlaceholders(appSpec) && err == nil {
+		err = fmt.Errorf(errorHandling.InvalidAppSpecPlaceholdersErr)
+	}
+
 	return err
 }
 
@@ -1,7 +1,7 @@
 package assistant
 
 // ECS AppSpec strings for Unit Tests
-var ecsOutputStr = `{0 [{{AWS::ECS::Service {[Your task definition arn] {[Your container Name] 8000} [Version number, ex: 1.3.0] {{[SubnetId1 SubnetId2] [ecs-security-group-1] DISABLED}}}}}] [map[BeforeInstall:BeforeInstallHookLambdaFunctionName] map[AfterInstall:AfterInstallHookLambdaFunctionName] map[AfterAllowTestTraffic:AfterAllowTestTrafficHookLambdaFunctionName] map[BeforeAllowTraffic:SanityTestHookLambdaFunctionName] map[AfterAllowTraffic:ValidationTestHookLambdaFunctionName]]}`
+var ecsOutputStr = `{0 [{{AWS::ECS::Service {[Your task definition arn] {[Your container Name] 8000} [Version number, ex: 1.3.0] {{[SubnetId1 SubnetId2] [ecs-security-group-1] DISABLED}} []}}}] [map[BeforeInstall:BeforeInstallHookLambdaFunctionName] map[AfterInstall:AfterInstallHookLambdaFunctionName] map[AfterAllowTestTraffic:AfterAllowTestTrafficHookLambdaFunctionName] map[BeforeAllowTraffic:SanityTestHookLambdaFunctionName] map[AfterAllowTraffic:ValidationTestHookLambdaFunctionName]]}`
 
 var ecsJsonString = `{
   "version": 0.0,
@@ -0,0 +1,157 @@
+package assistant
+
+import (
+	"regexp"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/globalVars"
+)
+
+// ARN parts: arn:partition:service:region:account-id:resource
+type awsArn struct {
+	partition string
+	service   string
+	region    string
+	accountId string
+	resource  string
+}
+
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+var awsAccountIdPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+// subnet-0123abcd (older 8 character IDs) or subnet-0123456789abcdef0
+var ecsSubnetIdPattern = regexp.MustCompile(`^subnet-([0-9a-f]{8}|[0-9a-f]{17})$`)
+var ecsSecurityGroupIdPattern = regexp.MustCompile(`^sg-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// family:revision, the family is up to 255 letters, numbers, hyphens, and underscores
+var ecsTaskDefinitionPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,255}:[1-9][0-9]*$`)
+
+var lambdaFunctionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Published versions start at 1
+var lambdaVersionPattern = regexp.MustCompile(`^[1-9][0-9]*$`)
+var lambdaAliasPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Alias names that Lambda rejects, digits only including 0 and leading zeros
+var lambdaNumericAliasPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// 123456789012:function:my-function
+var lambdaPartialFunctionArnPattern = regexp.MustCompile(`^[0-9]{12}:function:`)
+
+// Version or alias at the end of a function reference
+var lambdaQualifierPattern = regexp.MustCompile(`^(\$LATEST|[A-Za-z0-9_-]{1,128})$`)
+
+// Split an ARN into its parts
+// Returns false if it is not an ARN, or the region does not belong to the partition
+func parseAwsArn(arn string) (awsArn, bool) {
+	arnParts := strings.SplitN(arn, ":", 6)
+	if len(arnParts) != 6 || arnParts[0] != "arn" {
+		return awsArn{}, false
+	}
+
+	parsedArn := awsArn{
+		partition: arnParts[1],
+		service:   arnParts[2],
+		region:    arnParts[3],
+		accountId: arnParts[4],
+		resource:  arnParts[5],
+	}
+
+	if parsedArn.service == "" || parsedArn.resource == "" ||
+		!awsAccountIdPattern.MatchString(parsedArn.accountId) ||
+		!awsRegionPattern.MatchString(parsedArn.region) ||
+		getAwsPartitionForRegion(parsedArn.region) != parsedArn.partition {
+		return parsedArn, false
+	}
+
+	for _, partition := range globalVars.AwsPartitions {
+		if parsedArn.partition == partition {
+			return parsedArn, true
+		}
+	}
+
+	return parsedArn, false
+}
+
+func getAwsPartitionForRegion(region string) string {
+	for partition, regionPrefix := range globalVars.AwsPartitionRegionPrefixes {
+		if strings.HasPrefix(region, regionPrefix) {
+			return partition
+		}
+	}
+
+	return "aws"
+}
+
+func isValidEcsSubnetId(subnet string) bool {
+	return ecsSubnetIdPattern.MatchString(subnet)
+}
+
+func isValidEcsSecurityGroupId(securityGroup string) bool {
+	return ecsSecurityGroupIdPattern.MatchString(securityGroup)
+}
+
+// Task definition ARN (arn:aws:ecs:us-east-1:123456789012:task-definition/family:revision) or family:revision
+func isValidEcsTaskDefinition(taskDefinition string) bool {
+	if !strings.HasPrefix(taskDefinition, "arn:") {
+		return ecsTaskDefinitionPattern.MatchString(taskDefinition)
+	}
+
+	parsedArn, ok := parseAwsArn(taskDefinition)
+	if !ok || parsedArn.service != "ecs" || !strings.HasPrefix(parsedArn.resource, "task-definition/") {
+		return false
+	}
+
+	return ecsTaskDefinitionPattern.MatchString(strings.TrimPrefix(parsedArn.resource, "task-definition/"))
+}
+
+// Function name, partial ARN (123456789012:function:my-function), or function ARN
+// With allowQualifier the reference can end in a version or alias (my-function:live)
+func isValidLambdaFunctionReference(function string, allowQualifier bool) bool {
+	functionAndQualifier := function
+	if strings.HasPrefix(function, "arn:") {
+		parsedArn, ok := parseAwsArn(function)
+		if !ok || parsedArn.service != "lambda" || !strings.HasPrefix(parsedArn.resource, "function:") {
+			return false
+		}
+		functionAndQualifier = strings.TrimPrefix(parsedArn.resource, "function:")
+	} else if lambdaPartialFunctionArnPattern.MatchString(function) {
+		functionAndQualifier = lambdaPartialFunctionArnPattern.ReplaceAllString(function, "")
+	}
+
+	functionParts := strings.Split(functionAndQualifier, ":")
+	if len(functionParts) > 2 || (len(functionParts) == 2 && (!allowQualifier || !lambdaQualifierPattern.MatchString(functionParts[1]))) {
+		return false
+	}
+
+	return lambdaFunctionNamePattern.MatchString(functionParts[0])
+}
+
+// Find IDs that are in the list more than once
+// Empty values are reported on their own
+func getDuplicateValues(values []string) []string {
+	var duplicateValues []string
+	seenValues := map[string]int{}
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		seenValues[value]++
+		if seenValues[value] == 2 {
+			duplicateValues = append(duplicateValues, value)
+		}
+	}
+
+	return duplicateValues
+}
+
+// Get the function name out of a function name, partial ARN, or function ARN (with or without a qualifier)
+func getLambdaFunctionName(function string) string {
+	i := strings.Index(function, ":function:")
+	if i < 0 {
+		return strings.Split(function, ":")[0]
+	}
+
+	return strings.Split(function[i+len(":function:"):], ":")[0]
+}
@@ -0,0 +1,101 @@
+package assistant
+
+import (
+	"testing"
+)
+
+// Test parseAwsArn
+func TestParseAwsArn(t *testing.T) {
+	var tests = []struct {
+		name           string
+		arn            string
+		expectedOutput bool
+	}{
+		{"aws partition", "arn:aws:ecs:us-east-1:111122223333:task-definition/web:3", true},
+		{"aws-cn partition", "arn:aws-cn:ecs:cn-north-1:111122223333:task-definition/web:3", true},
+		{"aws-us-gov partition", "arn:aws-us-gov:lambda:us-gov-west-1:111122223333:function:hook", true},
+		{"China region in the aws partition", "arn:aws:ecs:cn-north-1:111122223333:task-definition/web:3", false},
+		{"GovCloud region in the aws partition", "arn:aws:ecs:us-gov-west-1:111122223333:task-definition/web:3", false},
+		{"Unknown partition", "arn:aws-moon:ecs:us-east-1:111122223333:task-definition/web:3", false},
+		{"Short account ID", "arn:aws:ecs:us-east-1:1111:task-definition/web:3", false},
+		{"Missing region", "arn:aws:ecs::111122223333:task-definition/web:3", false},
+		{"Not an ARN", "web:3", false},
+	}
+
+	for _, test := range tests {
+		if _, output := parseAwsArn(test.arn); output != test.expectedOutput {
+			t.Errorf("The parseAwsArn function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test isValidEcsSubnetId and isValidEcsSecurityGroupId
+func TestIsValidEcsAwsvpcIds(t *testing.T) {
+	var tests = []struct {
+		id             string
+		isValid        func(string) bool
+		expectedOutput bool
+	}{
+		{"subnet-0a1b2c3d", isValidEcsSubnetId, true},
+		{"subnet-0123456789abcdef0", isValidEcsSubnetId, true},
+		{"subnet-0A1B2C3D", isValidEcsSubnetId, false},
+		{"subnet-0a1b2c", isValidEcsSubnetId, false},
+		{"sg-0a1b2c3d", isValidEcsSubnetId, false},
+		{"sg-0123456789abcdef0", isValidEcsSecurityGroupId, true},
+		{"my-security-group", isValidEcsSecurityGroupId, false},
+		{" sg-0a1b2c3d", isValidEcsSecurityGroupId, false},
+	}
+
+	for _, test := range tests {
+		if output := test.isValid(test.id); output != test.expectedOutput {
+			t.Errorf("The ID validation returned %v for: %v", output, test.id)
+		}
+	}
+}
+
+// Test isValidEcsTaskDefinition
+func TestIsValidEcsTaskDefinition(t *testing.T) {
+	var tests = []struct {
+		taskDefinition string
+		expectedOutput bool
+	}{
+		{"arn:aws:ecs:us-east-1:111122223333:task-definition/web:3", true},
+		{"arn:aws-cn:ecs:cn-northwest-1:111122223333:task-definition/web_app-1:12", true},
+		{"web:3", true},
+		{"web", false},
+		{"web:0", false},
+		{"web:latest", false},
+		{"arn:aws:ecs:us-east-1:111122223333:service/web", false},
+		{"arn:aws:lambda:us-east-1:111122223333:task-definition/web:3", false},
+	}
+
+	for _, test := range tests {
+		if output := isValidEcsTaskDefinition(test.taskDefinition); output != test.expectedOutput {
+			t.Errorf("The isValidEcsTaskDefinition function returned %v for: %v", output, test.taskDefinition)
+		}
+	}
+}
+
+// Test isValidLambdaFunctionReference
+func TestIsValidLambdaFunctionReference(t *testing.T) {
+	var tests = []struct {
+		function       string
+		expectedOutput bool
+	}{
+		{"CodeDeployHook_BeforeInstall", true},
+		{"111122223333:function:CodeDeployHook_BeforeInstall", true},
+		{"arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeInstall", true},
+		{"CodeDeployHook_BeforeInstall:live", false},
+		{"arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeInstall:3", false},
+		{"arn:aws-us-gov:lambda:us-gov-east-1:111122223333:function:hook", true},
+		{"my hook", false},
+		{"arn:aws:ecs:us-east-1:111122223333:function:hook", false},
+		{"arn:aws:lambda:us-east-1:111122223333:layer:hook", false},
+	}
+
+	for _, test := range tests {
+		if output := isValidLambdaFunctionReference(test.function, false); output != test.expectedOutput {
+			t.Errorf("The isValidLambdaFunctionReference function returned %v for: %v", output, test.function)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package assistant
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/hex"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+)
+
+// Every entry of a bundle gets the same timestamp so the same content gives the same bytes
+// Zip files cannot store times before 1980
+var bundleModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// A file, directory, or symlink of a bundle, relative to its root
+type bundleEntry struct {
+	name       string
+	mode       os.FileMode
+	linkTarget string
+	content    []byte
+}
+
+// Main function of the bundle command
+func BundleRevision(appSpecFilePath string, outFilePath string, computePlatform string) {
+	fmt.Println("bundleRevision called on:", appSpecFilePath)
+
+	lowerOutFilePath := strings.ToLower(outFilePath)
+	isZip := strings.HasSuffix(lowerOutFilePath, ".zip")
+	if !isZip && !strings.HasSuffix(lowerOutFilePath, ".tgz") && !strings.HasSuffix(lowerOutFilePath, ".tar.gz") {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.UnsupportedBundleFormatErr, outFilePath))
+	}
+
+	revision, err := loadAppSpecRevision(filepath.Dir(appSpecFilePath))
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	appSpecBytes, err := ioutil.ReadFile(appSpecFilePath)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if computePlatform == "" {
+		computePlatform = detectAppSpecComputePlatform(appSpecBytes)
+	}
+
+	appSpecBytes, err = loadAppSpecFile(appSpecFilePath, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	// The files and scripts of a server AppSpec have to be next to it
+	if computePlatform == "server" {
+		serverRevision = revision
+	}
+
+	if validationErr := runValidation(appSpecBytes, computePlatform); validationErr != nil {
+		fmt.Println(errorHandling.InvalidBundleAppSpecErr)
+		errorHandling.HandleError(validationErr)
+	}
+
+	outFileAbsPath, _ := filepath.Abs(outFilePath)
+	entries, err := getBundleEntries(revision, filepath.Base(appSpecFilePath), appSpecBytes, computePlatform, outFileAbsPath)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	var bundleBytes []byte
+	if isZip {
+		bundleBytes, err = writeBundleZip(entries)
+	} else {
+		bundleBytes, err = writeBundleTgz(entries)
+	}
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := ioutil.WriteFile(outFilePath, bundleBytes, 0644); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	bundleMd5 := md5.Sum(bundleBytes)
+	fmt.Printf(errorHandling.WroteBundleMsg, outFilePath, len(entries), hex.EncodeToString(bundleMd5[:]), hex.EncodeToString(bundleMd5[:]))
+}
+
+// The AppSpec at the root, the files and scripts it references, and their directories, sorted
+// Scripts of Linux AppSpecs are made executable, every other file gets 0755 or 0644 depending on its executable bit
+func getBundleEntries(revision *appSpecRevision, appSpecName string, appSpecBytes []byte, computePlatform string, outFileAbsPath string) ([]bundleEntry, error) {
+	bundlePaths := map[string]bool{}
+	scriptPaths := map[string]bool{}
+	isLinux := false
+
+	if computePlatform == "server" {
+		serverAppSpecModel, err := getServerAppSpecObjFromString(appSpecBytes)
+		if err != nil {
+			return nil, err
+		}
+		isLinux = serverAppSpecModel.OS == "linux"
+
+		fileCopies, err := resolveServerFiles(serverAppSpecModel.Files, revision.getFiles())
+		if err != nil {
+			return nil, err
+		}
+		for _, fileCopy := range fileCopies {
+			bundlePaths[fileCopy.source] = true
+		}
+
+		for _, hookScripts := range serverAppSpecModel.Hooks {
+			for _, hookScript := range hookScripts {
+				location := cleanRevisionPath(hookScript.Location)
+				if _, ok := revision.getEntry(location); !ok {
+					return nil, fmt.Errorf(errorHandling.MissingBundleFileErr, hookScript.Location)
+				}
+				bundlePaths[location] = true
+				scriptPaths[location] = true
+			}
+		}
+	}
+
+	// A source of / would put the bundle in itself
+	if outFilePath, err := filepath.Rel(revision.path, outFileAbsPath); err == nil {
+		delete(bundlePaths, filepath.ToSlash(outFilePath))
+	}
+	delete(bundlePaths, appSpecName)
+
+	entries := []bundleEntry{{name: appSpecName, mode: 0644, content: appSpecBytes}}
+	dirs := map[string]bool{}
+
+	for bundlePath := range bundlePaths {
+		revisionEntry, _ := revision.getEntry(bundlePath)
+		entry := bundleEntry{name: bundlePath, mode: 0644, linkTarget: revisionEntry.linkTarget}
+
+		if revisionEntry.mode&os.ModeSymlink != 0 {
+			entry.mode = os.ModeSymlink | 0777
+		} else {
+			content, err := revision.readFile(revisionEntry.path)
+			if err != nil {
+				return nil, err
+			}
+			entry.content = content
+			if (scriptPaths[bundlePath] && isLinux) || revisionEntry.mode&0111 != 0 {
+				entry.mode = 0755
+			}
+		}
+		entries = append(entries, entry)
+
+		for dir := path.Dir(bundlePath); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	for dir := range dirs {
+		entries = append(entries, bundleEntry{name: dir + "/", mode: os.ModeDir | 0755})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+
+	return entries, nil
+}
+
+func writeBundleZip(entries []bundleEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: bundleModTime}
+		header.SetMode(entry.mode)
+
+		content := entry.content
+		if entry.mode&os.ModeSymlink != 0 {
+			content = []byte(entry.linkTarget)
+		}
+		if entry.mode.IsDir() || entry.mode&os.ModeSymlink != 0 {
+			header.Method = zip.Store
+		}
+
+		fileWriter, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fileWriter.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// No owner names, IDs, or gzip file name, so only the content counts
+func writeBundleTgz(entries []bundleEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: int64(entry.mode.Perm()), ModTime: bundleModTime, Typeflag: tar.TypeReg, Size: int64(len(entry.content))}
+		switch {
+		case entry.mode.IsDir():
+			header.Typeflag = tar.TypeDir
+		case entry.mode&os.ModeSymlink != 0:
+			header.Typeflag, header.Linkname, header.Size = tar.TypeSymlink, entry.linkTarget, 0
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(entry.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
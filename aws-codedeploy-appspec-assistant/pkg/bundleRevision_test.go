@@ -0,0 +1,132 @@
+package assistant
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"archive/zip"
+)
+
+var testBundleServerYamlString = `version: 0.0
+os: linux
+files:
+  - source: app
+    destination: /var/www
+hooks:
+  ApplicationStart:
+    - location: scripts/start.sh`
+
+func getTestBundleEntries(t *testing.T, revisionFiles map[string]string) ([]bundleEntry, error) {
+	revisionDirPath := writeTestRevisionDir(t, revisionFiles)
+	defer os.RemoveAll(revisionDirPath)
+
+	// Scripts are checked out without their executable bit
+	os.Chmod(filepath.Join(revisionDirPath, "scripts", "start.sh"), 0644)
+	os.Chmod(filepath.Join(revisionDirPath, "app", "index.html"), 0644)
+
+	revision, err := loadAppSpecRevision(revisionDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileExtension = "yml"
+	return getBundleEntries(revision, "appspec.yml", []byte(revisionFiles["appspec.yml"]), "server", filepath.Join(revisionDirPath, "app.zip"))
+}
+
+// Test getBundleEntries
+func TestGetBundleEntries(t *testing.T) {
+	entries, err := getTestBundleEntries(t, map[string]string{
+		"appspec.yml":      testBundleServerYamlString,
+		"app/index.html":   "<html></html>",
+		"scripts/start.sh": "#!/bin/sh\n",
+		"README.md":        "not referenced",
+	})
+	if err != nil {
+		t.Fatalf("The getBundleEntries function failed: %v", err)
+	}
+
+	var entryNames []string
+	for _, entry := range entries {
+		entryNames = append(entryNames, entry.name)
+		if entry.name == "scripts/start.sh" && entry.mode != 0755 {
+			t.Errorf("The getBundleEntries function did not make the Linux script executable: %v", entry.mode)
+		}
+		if entry.name == "app/index.html" && entry.mode != 0644 {
+			t.Errorf("The getBundleEntries function changed the mode of a file: %v", entry.mode)
+		}
+	}
+
+	expectedNames := "app/,app/index.html,appspec.yml,scripts/,scripts/start.sh"
+	if strings.Join(entryNames, ",") != expectedNames {
+		t.Errorf("The getBundleEntries function returned %v instead of %v", entryNames, expectedNames)
+	}
+
+	_, err = getTestBundleEntries(t, map[string]string{"appspec.yml": testBundleServerYamlString, "app/index.html": "", "scripts/stop.sh": ""})
+	if err == nil {
+		t.Errorf("The getBundleEntries function succeeded for a script that does not exist")
+	}
+}
+
+// Test the same entries always give the same bundle
+func TestWriteBundle_Reproducible(t *testing.T) {
+	entries := []bundleEntry{
+		{name: "appspec.yml", mode: 0644, content: []byte(testBundleServerYamlString)},
+		{name: "scripts/", mode: os.ModeDir | 0755},
+		{name: "scripts/start.sh", mode: 0755, content: []byte("#!/bin/sh\n")},
+	}
+
+	for _, writeBundle := range []func([]bundleEntry) ([]byte, error){writeBundleZip, writeBundleTgz} {
+		firstBytes, err := writeBundle(entries)
+		if err != nil {
+			t.Fatalf("Writing the bundle failed: %v", err)
+		}
+
+		secondBytes, _ := writeBundle(entries)
+		if !bytes.Equal(firstBytes, secondBytes) {
+			t.Errorf("Writing the same entries twice gave different bundles")
+		}
+	}
+
+	zipBytes, _ := writeBundleZip(entries)
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zipReader.File[0].Name != "appspec.yml" || zipReader.File[2].Mode().Perm() != 0755 || !zipReader.File[2].Modified.Equal(bundleModTime) {
+		t.Errorf("The zip bundle has the entries %v, %v, %v", zipReader.File[0].Name, zipReader.File[2].Mode(), zipReader.File[2].Modified)
+	}
+}
+
+// Test a bundle can be loaded as a revision
+func TestWriteBundle_Revision(t *testing.T) {
+	entries, err := getTestBundleEntries(t, map[string]string{
+		"appspec.yml":      testBundleServerYamlString,
+		"app/index.html":   "<html></html>",
+		"scripts/start.sh": "#!/bin/sh\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundleDirPath, _ := ioutil.TempDir("", "bundle")
+	defer os.RemoveAll(bundleDirPath)
+
+	tgzBytes, _ := writeBundleTgz(entries)
+	tgzPath := filepath.Join(bundleDirPath, "app.tgz")
+	ioutil.WriteFile(tgzPath, tgzBytes, 0644)
+
+	revision, err := loadAppSpecRevision(tgzPath)
+	if err != nil {
+		t.Fatalf("The tgz bundle could not be loaded: %v", err)
+	}
+	if revision.rootDir != "" || strings.Join(revision.getFiles(), ",") != "app/index.html,appspec.yml,scripts/start.sh" {
+		t.Errorf("The tgz bundle has the AppSpec root %q and files %v", revision.rootDir, revision.getFiles())
+	}
+	if entry, _ := revision.getEntry("scripts/start.sh"); entry.mode.Perm() != 0755 {
+		t.Errorf("The tgz bundle has the script mode %v", entry.mode)
+	}
+}
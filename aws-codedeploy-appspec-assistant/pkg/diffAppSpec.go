@@ -0,0 +1,528 @@
+package assistant
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Risk levels of AppSpec changes, from most to least risky
+const (
+	highRisk   = "HIGH"
+	mediumRisk = "MEDIUM"
+	lowRisk    = "LOW"
+)
+
+var appSpecChangeRisks = [...]string{highRisk, mediumRisk, lowRisk}
+
+type appSpecChange struct {
+	risk        string
+	description string
+}
+
+// A hook of an ECS or Lambda AppSpec with the function it calls
+type appSpecHook struct {
+	name     string
+	function string
+}
+
+// Main function of the diff command
+func DiffAppSpecs(oldFilePath string, newFilePath string, computePlatform string) {
+	changes, err := diffAppSpecFiles(oldFilePath, newFilePath, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if len(changes) < 1 {
+		fmt.Println(errorHandling.NoAppSpecChangesMsg)
+		return
+	}
+
+	fmt.Printf(errorHandling.AppSpecChangesHeaderMsg, oldFilePath, newFilePath)
+	numOfChanges := map[string]int{}
+	for _, change := range changes {
+		numOfChanges[change.risk]++
+		fmt.Printf("[%s] %s\n", change.risk, change.description)
+	}
+
+	fmt.Printf(errorHandling.AppSpecChangesSummaryMsg, numOfChanges[highRisk], numOfChanges[mediumRisk], numOfChanges[lowRisk])
+}
+
+// Load both AppSpec files as models and compare them
+// The changes are sorted from most to least risky
+func diffAppSpecFiles(oldFilePath string, newFilePath string, computePlatform string) ([]appSpecChange, error) {
+	oldAppSpecBytes, err := loadAppSpecFile(oldFilePath, computePlatform)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []appSpecChange
+
+	if computePlatform == "ecs" {
+		oldAppSpecModel, modelErr := getEcsAppSpecObjFromString(oldAppSpecBytes)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		newAppSpecBytes, err := loadAppSpecFile(newFilePath, computePlatform)
+		if err != nil {
+			return nil, err
+		}
+		newAppSpecModel, modelErr := getEcsAppSpecObjFromString(newAppSpecBytes)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		changes = diffEcsAppSpecs(oldAppSpecModel, newAppSpecModel)
+	} else if computePlatform == "lambda" {
+		oldAppSpecModel, modelErr := getLambdaAppSpecObjFromString(oldAppSpecBytes)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		newAppSpecBytes, err := loadAppSpecFile(newFilePath, computePlatform)
+		if err != nil {
+			return nil, err
+		}
+		newAppSpecModel, modelErr := getLambdaAppSpecObjFromString(newAppSpecBytes)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		changes = diffLambdaAppSpecs(oldAppSpecModel, newAppSpecModel)
+	} else {
+		oldAppSpecModel, modelErr := getServerAppSpecObjFromString(oldAppSpecBytes)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		newAppSpecBytes, err := loadAppSpecFile(newFilePath, computePlatform)
+		if err != nil {
+			return nil, err
+		}
+		newAppSpecModel, modelErr := getServerAppSpecObjFromString(newAppSpecBytes)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		changes = diffServerAppSpecs(oldAppSpecModel, newAppSpecModel)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return getRiskRank(changes[i].risk) < getRiskRank(changes[j].risk)
+	})
+
+	return changes, nil
+}
+
+func getRiskRank(risk string) int {
+	for i, appSpecChangeRisk := range appSpecChangeRisks {
+		if risk == appSpecChangeRisk {
+			return i
+		}
+	}
+
+	return len(appSpecChangeRisks)
+}
+
+func newAppSpecChange(risk string, format string, a ...interface{}) appSpecChange {
+	return appSpecChange{risk, fmt.Sprintf(format, a...)}
+}
+
+// ECS diff
+func diffEcsAppSpecs(oldAppSpec models.EcsAppSpecModel, newAppSpec models.EcsAppSpecModel) []appSpecChange {
+	var changes []appSpecChange
+
+	// Only 1 TargetService is supported, so the resources are compared by position
+	for i := 0; i < len(oldAppSpec.Resources) || i < len(newAppSpec.Resources); i++ {
+		if i >= len(newAppSpec.Resources) {
+			changes = append(changes, newAppSpecChange(highRisk, "ECS TargetService removed: %s", oldAppSpec.Resources[i].TargetService.Properties.TaskDefinition))
+			continue
+		}
+		if i >= len(oldAppSpec.Resources) {
+			changes = append(changes, newAppSpecChange(highRisk, "ECS TargetService added: %s", newAppSpec.Resources[i].TargetService.Properties.TaskDefinition))
+			continue
+		}
+
+		oldProps := oldAppSpec.Resources[i].TargetService.Properties
+		newProps := newAppSpec.Resources[i].TargetService.Properties
+
+		if oldProps.TaskDefinition != newProps.TaskDefinition {
+			changes = append(changes, newAppSpecChange(mediumRisk, "ECS TaskDefinition changed: %s -> %s", oldProps.TaskDefinition, newProps.TaskDefinition))
+		}
+		if oldProps.LoadBalancerInfo.ContainerName != newProps.LoadBalancerInfo.ContainerName {
+			changes = append(changes, newAppSpecChange(highRisk, "ECS ContainerName changed, traffic is routed to a different container: %s -> %s", oldProps.LoadBalancerInfo.ContainerName, newProps.LoadBalancerInfo.ContainerName))
+		}
+		if oldProps.LoadBalancerInfo.ContainerPort != newProps.LoadBalancerInfo.ContainerPort {
+			changes = append(changes, newAppSpecChange(highRisk, "ECS ContainerPort changed, traffic is routed to a different port: %d -> %d", oldProps.LoadBalancerInfo.ContainerPort, newProps.LoadBalancerInfo.ContainerPort))
+		}
+		if oldProps.PlatformVersion != newProps.PlatformVersion {
+			changes = append(changes, newAppSpecChange(mediumRisk, "ECS PlatformVersion changed: %s -> %s", getValueOrNotSet(oldProps.PlatformVersion), getValueOrNotSet(newProps.PlatformVersion)))
+		}
+
+		changes = append(changes, diffStringSets("ECS CapacityProviderStrategy", getEcsCapacityProviderStrategyItems(oldProps.CapacityProviderStrategy), getEcsCapacityProviderStrategyItems(newProps.CapacityProviderStrategy), mediumRisk)...)
+
+		oldAwsvpcConfig := oldProps.NetworkConfiguration.AwsvpcConfiguration
+		newAwsvpcConfig := newProps.NetworkConfiguration.AwsvpcConfiguration
+		changes = append(changes, diffStringSets("ECS Subnets", oldAwsvpcConfig.Subnets, newAwsvpcConfig.Subnets, highRisk)...)
+		changes = append(changes, diffStringSets("ECS SecurityGroups", oldAwsvpcConfig.SecurityGroups, newAwsvpcConfig.SecurityGroups, highRisk)...)
+		if oldAwsvpcConfig.AssignPublicIp != newAwsvpcConfig.AssignPublicIp {
+			changes = append(changes, newAppSpecChange(highRisk, "ECS AssignPublicIp changed: %s -> %s", getValueOrNotSet(oldAwsvpcConfig.AssignPublicIp), getValueOrNotSet(newAwsvpcConfig.AssignPublicIp)))
+		}
+	}
+
+	changes = append(changes, diffHookLists("ECS", getAppSpecHookList(oldAppSpec.Hooks), getAppSpecHookList(newAppSpec.Hooks))...)
+
+	return changes
+}
+
+func getEcsCapacityProviderStrategyItems(capacityProviderStrategy []models.CapacityProviderStrategyItem) []string {
+	var items []string
+	for _, capacityProviderStrategyItem := range capacityProviderStrategy {
+		items = append(items, fmt.Sprintf("%s (base %d, weight %d)", capacityProviderStrategyItem.CapacityProvider, capacityProviderStrategyItem.Base, capacityProviderStrategyItem.Weight))
+	}
+
+	return items
+}
+
+// Lambda diff
+func diffLambdaAppSpecs(oldAppSpec models.LambdaAppSpecModel, newAppSpec models.LambdaAppSpecModel) []appSpecChange {
+	var changes []appSpecChange
+
+	oldFunctions := getLambdaFunctionsByResourceName(oldAppSpec.Resources)
+	newFunctions := getLambdaFunctionsByResourceName(newAppSpec.Resources)
+
+	for _, resourceName := range getSortedFunctionKeys(oldFunctions, newFunctions) {
+		oldFunction, inOld := oldFunctions[resourceName]
+		newFunction, inNew := newFunctions[resourceName]
+
+		if !inNew {
+			changes = append(changes, newAppSpecChange(highRisk, "Lambda function resource removed: %s", resourceName))
+			continue
+		}
+		if !inOld {
+			changes = append(changes, newAppSpecChange(highRisk, "Lambda function resource added: %s", resourceName))
+			continue
+		}
+
+		oldProps := oldFunction.Properties
+		newProps := newFunction.Properties
+
+		if oldProps.Name != newProps.Name {
+			changes = append(changes, newAppSpecChange(highRisk, "Lambda %s Name changed, a different function is deployed: %s -> %s", resourceName, oldProps.Name, newProps.Name))
+		}
+		if oldProps.Alias != newProps.Alias {
+			changes = append(changes, newAppSpecChange(highRisk, "Lambda %s Alias changed, traffic shifts on a different alias: %s -> %s", resourceName, oldProps.Alias, newProps.Alias))
+		}
+		if oldProps.CurrentVersion != newProps.CurrentVersion {
+			changes = append(changes, newAppSpecChange(mediumRisk, "Lambda %s CurrentVersion changed: %s -> %s", resourceName, oldProps.CurrentVersion, newProps.CurrentVersion))
+		}
+		if oldProps.TargetVersion != newProps.TargetVersion {
+			changes = append(changes, newAppSpecChange(mediumRisk, "Lambda %s TargetVersion bumped: %s -> %s", resourceName, oldProps.TargetVersion, newProps.TargetVersion))
+		}
+	}
+
+	changes = append(changes, diffHookLists("Lambda", getAppSpecHookList(oldAppSpec.Hooks), getAppSpecHookList(newAppSpec.Hooks))...)
+
+	return changes
+}
+
+func getLambdaFunctionsByResourceName(lambdaResources []map[string]models.Function) map[string]models.Function {
+	functions := map[string]models.Function{}
+
+	for _, lambdaResource := range lambdaResources {
+		for functionResourceName, function := range lambdaResource {
+			functions[functionResourceName] = function
+		}
+	}
+
+	return functions
+}
+
+// Server (EC2/On-Prem) diff
+func diffServerAppSpecs(oldAppSpec models.ServerAppSpecModel, newAppSpec models.ServerAppSpecModel) []appSpecChange {
+	var changes []appSpecChange
+
+	if oldAppSpec.OS != newAppSpec.OS {
+		changes = append(changes, newAppSpecChange(highRisk, "OS changed: %s -> %s", oldAppSpec.OS, newAppSpec.OS))
+	}
+
+	changes = append(changes, diffServerFiles(oldAppSpec.Files, newAppSpec.Files)...)
+	changes = append(changes, diffServerPermissions(oldAppSpec.Permissions, newAppSpec.Permissions)...)
+	changes = append(changes, diffServerHooks(oldAppSpec.Hooks, newAppSpec.Hooks)...)
+
+	return changes
+}
+
+func diffServerFiles(oldFiles []models.File, newFiles []models.File) []appSpecChange {
+	var changes []appSpecChange
+
+	oldDestinations := map[string][]string{}
+	for _, file := range oldFiles {
+		oldDestinations[file.Source] = append(oldDestinations[file.Source], file.Destination)
+	}
+	newDestinations := map[string][]string{}
+	for _, file := range newFiles {
+		newDestinations[file.Source] = append(newDestinations[file.Source], file.Destination)
+	}
+
+	for _, source := range getSortedStringListKeys(oldDestinations, newDestinations) {
+		oldDestination := strings.Join(oldDestinations[source], ", ")
+		newDestination := strings.Join(newDestinations[source], ", ")
+
+		if _, ok := newDestinations[source]; !ok {
+			changes = append(changes, newAppSpecChange(mediumRisk, "files source removed, it is no longer deployed: %s (was copied to %s)", source, oldDestination))
+		} else if _, ok := oldDestinations[source]; !ok {
+			changes = append(changes, newAppSpecChange(lowRisk, "files source added: %s -> %s", source, newDestination))
+		} else if oldDestination != newDestination {
+			changes = append(changes, newAppSpecChange(highRisk, "files destination moved for %s: %s -> %s", source, oldDestination, newDestination))
+		}
+	}
+
+	return changes
+}
+
+func diffServerPermissions(oldPermissions []models.Permission, newPermissions []models.Permission) []appSpecChange {
+	var changes []appSpecChange
+
+	oldPermissionsByObject := getServerPermissionsByObject(oldPermissions)
+	newPermissionsByObject := getServerPermissionsByObject(newPermissions)
+
+	for _, object := range getSortedPermissionKeys(oldPermissionsByObject, newPermissionsByObject) {
+		oldPermission, inOld := oldPermissionsByObject[object]
+		newPermission, inNew := newPermissionsByObject[object]
+
+		if !inNew {
+			changes = append(changes, newAppSpecChange(mediumRisk, "permissions removed for %s", object))
+			continue
+		}
+		if !inOld {
+			changes = append(changes, newAppSpecChange(mediumRisk, "permissions added for %s", object))
+			continue
+		}
+
+		if oldPermission.Mode != newPermission.Mode {
+			changes = append(changes, newAppSpecChange(highRisk, "permissions mode changed for %s: %s -> %s", object, getValueOrNotSet(oldPermission.Mode), getValueOrNotSet(newPermission.Mode)))
+		}
+		if oldPermission.Owner != newPermission.Owner {
+			changes = append(changes, newAppSpecChange(highRisk, "permissions owner changed for %s: %s -> %s", object, getValueOrNotSet(oldPermission.Owner), getValueOrNotSet(newPermission.Owner)))
+		}
+		if oldPermission.Group != newPermission.Group {
+			changes = append(changes, newAppSpecChange(highRisk, "permissions group changed for %s: %s -> %s", object, getValueOrNotSet(oldPermission.Group), getValueOrNotSet(newPermission.Group)))
+		}
+		changes = append(changes, diffStringSets("permissions acls for "+object, oldPermission.Acls, newPermission.Acls, highRisk)...)
+		if oldPermission.Context != newPermission.Context {
+			changes = append(changes, newAppSpecChange(highRisk, "permissions SELinux context changed for %s: %v -> %v", object, oldPermission.Context, newPermission.Context))
+		}
+		if oldPermission.Except != newPermission.Except {
+			changes = append(changes, newAppSpecChange(mediumRisk, "permissions except changed for %s: %s -> %s", object, getValueOrNotSet(oldPermission.Except), getValueOrNotSet(newPermission.Except)))
+		}
+		changes = append(changes, diffStringSets("permissions type for "+object, oldPermission.Type, newPermission.Type, mediumRisk)...)
+	}
+
+	return changes
+}
+
+// Permissions are matched by object and pattern since they are what select the files
+func getServerPermissionsByObject(permissions []models.Permission) map[string]models.Permission {
+	permissionsByObject := map[string]models.Permission{}
+
+	for _, permission := range permissions {
+		object := permission.Object
+		if permission.Pattern != "" {
+			object += " (pattern " + permission.Pattern + ")"
+		}
+		permissionsByObject[object] = permission
+	}
+
+	return permissionsByObject
+}
+
+func diffServerHooks(oldHooks map[string][]models.Hook, newHooks map[string][]models.Hook) []appSpecChange {
+	var changes []appSpecChange
+
+	for _, hook := range getSortedHookKeys(oldHooks, newHooks) {
+		oldScripts, inOld := oldHooks[hook]
+		newScripts, inNew := newHooks[hook]
+
+		if !inNew {
+			changes = append(changes, newAppSpecChange(mediumRisk, "hook removed: %s (%d scripts no longer run)", hook, len(oldScripts)))
+			continue
+		}
+		if !inOld {
+			changes = append(changes, newAppSpecChange(lowRisk, "hook added: %s (%d scripts)", hook, len(newScripts)))
+			continue
+		}
+
+		oldScriptsByLocation := map[string]models.Hook{}
+		var oldLocations []string
+		for _, script := range oldScripts {
+			oldScriptsByLocation[script.Location] = script
+			oldLocations = append(oldLocations, script.Location)
+		}
+		newScriptsByLocation := map[string]models.Hook{}
+		var newLocations []string
+		for _, script := range newScripts {
+			newScriptsByLocation[script.Location] = script
+			newLocations = append(newLocations, script.Location)
+		}
+
+		for _, location := range oldLocations {
+			newScript, ok := newScriptsByLocation[location]
+			if !ok {
+				changes = append(changes, newAppSpecChange(mediumRisk, "%s script removed: %s", hook, location))
+				continue
+			}
+			oldScript := oldScriptsByLocation[location]
+
+			if getScriptTimeout(oldScript) != getScriptTimeout(newScript) {
+				risk := lowRisk
+				if getScriptTimeout(newScript) < getScriptTimeout(oldScript) {
+					// A shorter timeout can fail deployments that used to pass
+					risk = mediumRisk
+				}
+				changes = append(changes, newAppSpecChange(risk, "%s script %s timeout changed: %ds -> %ds", hook, location, getScriptTimeout(oldScript), getScriptTimeout(newScript)))
+			}
+			if oldScript.Runas != newScript.Runas {
+				changes = append(changes, newAppSpecChange(highRisk, "%s script %s runas changed: %s -> %s", hook, location, getValueOrNotSet(oldScript.Runas), getValueOrNotSet(newScript.Runas)))
+			}
+		}
+		for _, location := range newLocations {
+			if _, ok := oldScriptsByLocation[location]; !ok {
+				changes = append(changes, newAppSpecChange(lowRisk, "%s script added: %s", hook, location))
+			}
+		}
+
+		// Scripts within a lifecycle event run in the order they are listed
+		if oldOrder, newOrder := getCommonOrder(oldLocations, newLocations); oldOrder != newOrder {
+			changes = append(changes, newAppSpecChange(mediumRisk, "%s scripts reordered: %s -> %s", hook, oldOrder, newOrder))
+		}
+	}
+
+	return changes
+}
+
+// Scripts without a timeout get the maximum of 3600 seconds
+func getScriptTimeout(script models.Hook) int {
+	var timeout int
+	if _, err := fmt.Sscanf(script.Timeout, "%d", &timeout); err != nil {
+		return 3600
+	}
+
+	return timeout
+}
+
+// ECS and Lambda hooks diff
+func diffHookLists(platform string, oldHooks []appSpecHook, newHooks []appSpecHook) []appSpecChange {
+	var changes []appSpecChange
+
+	oldFunctions := map[string]string{}
+	var oldNames []string
+	for _, hook := range oldHooks {
+		oldFunctions[hook.name] = hook.function
+		oldNames = append(oldNames, hook.name)
+	}
+	newFunctions := map[string]string{}
+	var newNames []string
+	for _, hook := range newHooks {
+		newFunctions[hook.name] = hook.function
+		newNames = append(newNames, hook.name)
+	}
+
+	for _, hook := range oldHooks {
+		newFunction, ok := newFunctions[hook.name]
+		if !ok {
+			changes = append(changes, newAppSpecChange(mediumRisk, "%s hook removed, %s no longer runs: %s", platform, hook.function, hook.name))
+		} else if newFunction != hook.function {
+			changes = append(changes, newAppSpecChange(mediumRisk, "%s hook %s function changed: %s -> %s", platform, hook.name, hook.function, newFunction))
+		}
+	}
+	for _, hook := range newHooks {
+		if _, ok := oldFunctions[hook.name]; !ok {
+			changes = append(changes, newAppSpecChange(lowRisk, "%s hook added: %s -> %s", platform, hook.name, hook.function))
+		}
+	}
+
+	if oldOrder, newOrder := getCommonOrder(oldNames, newNames); oldOrder != newOrder {
+		changes = append(changes, newAppSpecChange(lowRisk, "%s hooks reordered (CodeDeploy still runs them in lifecycle order): %s -> %s", platform, oldOrder, newOrder))
+	}
+
+	return changes
+}
+
+// Flatten the Hooks list of ECS and Lambda AppSpecs ([{<Hook>: <Function>}, ...]) keeping its order
+func getAppSpecHookList(hooks []map[string]string) []appSpecHook {
+	var hookList []appSpecHook
+
+	for _, hookMap := range hooks {
+		var names []string
+		for name := range hookMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hookList = append(hookList, appSpecHook{name, hookMap[name]})
+		}
+	}
+
+	return hookList
+}
+
+// Order of the values that are in both lists, used to find reordered values
+func getCommonOrder(oldValues []string, newValues []string) (string, string) {
+	inOld := map[string]bool{}
+	for _, value := range oldValues {
+		inOld[value] = true
+	}
+	inNew := map[string]bool{}
+	for _, value := range newValues {
+		inNew[value] = true
+	}
+
+	var oldOrder []string
+	for _, value := range oldValues {
+		if inNew[value] {
+			oldOrder = append(oldOrder, value)
+		}
+	}
+	var newOrder []string
+	for _, value := range newValues {
+		if inOld[value] {
+			newOrder = append(newOrder, value)
+		}
+	}
+
+	return strings.Join(oldOrder, ", "), strings.Join(newOrder, ", ")
+}
+
+func diffStringSets(name string, oldValues []string, newValues []string, risk string) []appSpecChange {
+	var changes []appSpecChange
+
+	inOld := map[string]bool{}
+	for _, value := range oldValues {
+		inOld[value] = true
+	}
+	inNew := map[string]bool{}
+	for _, value := range newValues {
+		inNew[value] = true
+	}
+
+	var removed []string
+	for _, value := range oldValues {
+		if !inNew[value] {
+			removed = append(removed, value)
+		}
+	}
+	var added []string
+	for _, value := range newValues {
+		if !inOld[value] {
+			added = append(added, value)
+		}
+	}
+
+	if len(removed) > 0 {
+		changes = append(changes, newAppSpecChange(risk, "%s removed: %s", name, strings.Join(removed, ", ")))
+	}
+	if len(added) > 0 {
+		changes = append(changes, newAppSpecChange(risk, "%s added: %s", name, strings.Join(added, ", ")))
+	}
+
+	return changes
+}
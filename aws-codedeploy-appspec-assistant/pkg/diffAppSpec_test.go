@@ -0,0 +1,112 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+func containsAppSpecChange(changes []appSpecChange, risk string, descriptionSubStr string) bool {
+	for _, change := range changes {
+		if change.risk == risk && strings.Contains(change.description, descriptionSubStr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Test diffEcsAppSpecs
+func TestDiffEcsAppSpecs(t *testing.T) {
+	fileExtension = "yml"
+	oldAppSpec, _ := getEcsAppSpecObjFromString([]byte(ecsYamlString))
+	newAppSpec, _ := getEcsAppSpecObjFromString([]byte(ecsYamlString))
+
+	if changes := diffEcsAppSpecs(oldAppSpec, newAppSpec); len(changes) != 0 {
+		t.Errorf("The diffEcsAppSpecs function found changes between equal AppSpecs: %v", changes)
+	}
+
+	newAppSpec.Resources[0].TargetService.Properties.TaskDefinition = "newTaskDefinition"
+	newAppSpec.Resources[0].TargetService.Properties.LoadBalancerInfo.ContainerPort = 8080
+	newAppSpec.Resources[0].TargetService.Properties.CapacityProviderStrategy = []models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE_SPOT", Weight: 1}}
+	newAppSpec.Hooks = []map[string]string{
+		{"AfterInstall": "AfterInstallHookLambdaFunctionName"},
+		{"BeforeInstall": "BeforeInstallHookLambdaFunctionName"},
+		{"BeforeAllowTraffic": "OtherSanityTestHookLambdaFunctionName"},
+		{"AfterAllowTraffic": "ValidationTestHookLambdaFunctionName"},
+	}
+
+	var tests = []struct {
+		name              string
+		risk              string
+		descriptionSubStr string
+	}{
+		{"TaskDefinition", mediumRisk, "TaskDefinition changed"},
+		{"ContainerPort", highRisk, "ContainerPort changed, traffic is routed to a different port: 8000 -> 8080"},
+		{"CapacityProviderStrategy", mediumRisk, "ECS CapacityProviderStrategy added: FARGATE_SPOT (base 0, weight 1)"},
+		{"Hook removed", mediumRisk, "hook removed, AfterAllowTestTrafficHookLambdaFunctionName no longer runs"},
+		{"Hook function", mediumRisk, "hook BeforeAllowTraffic function changed"},
+		{"Hooks reordered", lowRisk, "hooks reordered"},
+	}
+
+	changes := diffEcsAppSpecs(oldAppSpec, newAppSpec)
+	for _, test := range tests {
+		if !containsAppSpecChange(changes, test.risk, test.descriptionSubStr) {
+			t.Errorf("The diffEcsAppSpecs function did not report the change for: %v. Got: %v", test.name, changes)
+		}
+	}
+}
+
+// Test diffLambdaAppSpecs
+func TestDiffLambdaAppSpecs(t *testing.T) {
+	fileExtension = "yml"
+	oldAppSpec, _ := getLambdaAppSpecObjFromString([]byte(lambdaYamlString))
+	newAppSpec, _ := getLambdaAppSpecObjFromString([]byte(strings.Replace(lambdaYamlString, `TargetVersion: "2"`, `TargetVersion: "3"`, 1)))
+
+	changes := diffLambdaAppSpecs(oldAppSpec, newAppSpec)
+	if len(changes) != 1 || !containsAppSpecChange(changes, mediumRisk, "TargetVersion bumped: 2 -> 3") {
+		t.Errorf("The diffLambdaAppSpecs function did not report the TargetVersion bump. Got: %v", changes)
+	}
+}
+
+// Test diffServerAppSpecs
+func TestDiffServerAppSpecs(t *testing.T) {
+	oldAppSpec := models.ServerAppSpecModel{
+		OS:          "linux",
+		Files:       []models.File{{Source: "app", Destination: "/var/www"}},
+		Permissions: []models.Permission{{Object: "/var/www", Mode: "644"}},
+		Hooks: map[string][]models.Hook{
+			"BeforeInstall": {{Location: "scripts/a.sh", Timeout: "300"}, {Location: "scripts/b.sh"}},
+		},
+	}
+	newAppSpec := models.ServerAppSpecModel{
+		OS:          "linux",
+		Files:       []models.File{{Source: "app", Destination: "/opt/www"}, {Source: "config", Destination: "/etc/app"}},
+		Permissions: []models.Permission{{Object: "/var/www", Mode: "777"}},
+		Hooks: map[string][]models.Hook{
+			"BeforeInstall":   {{Location: "scripts/b.sh"}, {Location: "scripts/a.sh", Timeout: "60"}},
+			"ValidateService": {{Location: "scripts/c.sh"}},
+		},
+	}
+
+	var tests = []struct {
+		name              string
+		risk              string
+		descriptionSubStr string
+	}{
+		{"Destination moved", highRisk, "files destination moved for app: /var/www -> /opt/www"},
+		{"Source added", lowRisk, "files source added: config"},
+		{"Mode", highRisk, "permissions mode changed for /var/www: 644 -> 777"},
+		{"Timeout", mediumRisk, "BeforeInstall script scripts/a.sh timeout changed: 300s -> 60s"},
+		{"Scripts reordered", mediumRisk, "BeforeInstall scripts reordered"},
+		{"Hook added", lowRisk, "hook added: ValidateService"},
+	}
+
+	changes := diffServerAppSpecs(oldAppSpec, newAppSpec)
+	for _, test := range tests {
+		if !containsAppSpecChange(changes, test.risk, test.descriptionSubStr) {
+			t.Errorf("The diffServerAppSpecs function did not report the change for: %v. Got: %v", test.name, changes)
+		}
+	}
+}
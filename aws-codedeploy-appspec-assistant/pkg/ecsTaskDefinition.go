@@ -0,0 +1,208 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"encoding/json"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Set by the validate command
+// In pipeline mode the TaskDefinition must be <TASK_DEFINITION> and the sibling taskdef.json is loaded
+// EcsTaskDefinitionFilePath is a local task definition to cross-check against instead of the sibling taskdef.json
+var EcsPipelineMode bool
+var EcsTaskDefinitionFilePath string
+
+// Task definition the ECS AppSpec is cross-checked against, nil if there is none
+var ecsTaskDefinition *models.EcsTaskDefinitionModel
+
+// <IMAGE1_NAME> placeholders that CodePipeline replaces in taskdef.json
+var ecsPipelineImagePlaceholderPattern = regexp.MustCompile(`^<IMAGE[0-9]*_NAME>$`)
+
+// Load the task definition to cross-check the ECS AppSpec against
+func setupEcsTaskDefinition(appSpecFilePath string) error {
+	ecsTaskDefinition = nil
+
+	taskDefinitionFilePath := EcsTaskDefinitionFilePath
+	if taskDefinitionFilePath == "" {
+		if !EcsPipelineMode {
+			return nil
+		}
+		taskDefinitionFilePath = filepath.Join(filepath.Dir(appSpecFilePath), globalVars.EcsPipelineTaskDefinitionFileName)
+	}
+
+	taskDefinition, err := loadEcsTaskDefinitionFile(taskDefinitionFilePath)
+	if err != nil {
+		return err
+	}
+	ecsTaskDefinition = &taskDefinition
+
+	if EcsPipelineMode && !validateEcsPipelineTaskDefinitionImages(taskDefinition) {
+		fmt.Println(errorHandling.MissingEcsPipelineImagePlaceholderWarn, taskDefinitionFilePath)
+	}
+
+	return nil
+}
+
+func loadEcsTaskDefinitionFile(taskDefinitionFilePath string) (models.EcsTaskDefinitionModel, error) {
+	taskDefinitionBytes, err := ioutil.ReadFile(taskDefinitionFilePath)
+	if err != nil {
+		return models.EcsTaskDefinitionModel{}, err
+	}
+
+	taskDefinition, err := getEcsTaskDefinitionObjFromString(taskDefinitionBytes)
+	if err != nil {
+		return taskDefinition, fmt.Errorf(errorHandling.InvalidEcsTaskDefinitionFileErr, taskDefinitionFilePath, err)
+	}
+
+	return taskDefinition, nil
+}
+
+// Convert ECS task definition string to ECS task definition Object
+// Deals with taskdef.json files and saved describe-task-definition output
+func getEcsTaskDefinitionObjFromString(taskDefinitionBytes []byte) (models.EcsTaskDefinitionModel, error) {
+	var describeTaskDefinition models.EcsDescribeTaskDefinitionModel
+	if err := json.Unmarshal(taskDefinitionBytes, &describeTaskDefinition); err == nil && describeTaskDefinition.TaskDefinition != nil {
+		return *describeTaskDefinition.TaskDefinition, nil
+	}
+
+	var taskDefinition models.EcsTaskDefinitionModel
+
+	err := json.Unmarshal(taskDefinitionBytes, &taskDefinition)
+
+	return taskDefinition, err
+}
+
+// Validate the TaskDefinition of the AppSpec (ARN or family:revision) is the task definition that was loaded
+func validateEcsTaskDefinitionIdentifier(taskDefinition string) bool {
+	// The family and revision are only known after CodePipeline registered the task definition
+	if taskDefinition == globalVars.EcsPipelineTaskDefinitionPlaceholder {
+		return true
+	}
+
+	family, revision := getEcsTaskDefinitionFamilyAndRevision(taskDefinition)
+
+	loadedFamily, loadedRevision := ecsTaskDefinition.Family, ecsTaskDefinition.Revision
+	if ecsTaskDefinition.TaskDefinitionArn != "" {
+		arnFamily, arnRevision := getEcsTaskDefinitionFamilyAndRevision(ecsTaskDefinition.TaskDefinitionArn)
+		if loadedFamily == "" {
+			loadedFamily = arnFamily
+		}
+		if loadedRevision == 0 {
+			loadedRevision = arnRevision
+		}
+	}
+
+	if loadedFamily != "" && family != loadedFamily {
+		numOfErrors++
+		fmt.Printf(errorHandling.MismatchedEcsTaskDefinitionFamilyErr, loadedFamily, taskDefinition)
+		return false
+	}
+
+	// Task definitions that were not registered yet have no revision
+	if loadedRevision != 0 && revision != 0 && revision != loadedRevision {
+		numOfErrors++
+		fmt.Printf(errorHandling.MismatchedEcsTaskDefinitionRevisionErr, loadedRevision, taskDefinition)
+		return false
+	}
+
+	return true
+}
+
+// Get the family and revision out of a task definition ARN, family:revision, or family
+// The revision is 0 if there is none
+func getEcsTaskDefinitionFamilyAndRevision(taskDefinition string) (string, int) {
+	familyAndRevision := taskDefinition
+	if i := strings.LastIndex(familyAndRevision, "task-definition/"); i >= 0 {
+		familyAndRevision = familyAndRevision[i+len("task-definition/"):]
+	}
+
+	i := strings.LastIndex(familyAndRevision, ":")
+	if i < 0 {
+		return familyAndRevision, 0
+	}
+
+	revision, err := strconv.Atoi(familyAndRevision[i+1:])
+	if err != nil {
+		return familyAndRevision, 0
+	}
+
+	return familyAndRevision[:i], revision
+}
+
+// The PlatformVersion only applies to task definitions that can run on Fargate
+func validateEcsTaskDefinitionCompatibilities(platformVersion string, taskDefinition string) bool {
+	if platformVersion == "" || len(ecsTaskDefinition.RequiresCompatibilities) < 1 {
+		return true
+	}
+
+	for _, compatibility := range ecsTaskDefinition.RequiresCompatibilities {
+		if compatibility == "FARGATE" {
+			return true
+		}
+	}
+
+	numOfErrors++
+	fmt.Printf(errorHandling.InvalidEcsTaskDefinitionCompatibilitiesErr, ecsTaskDefinition.RequiresCompatibilities, taskDefinition)
+
+	return false
+}
+
+// Validate the ContainerName and ContainerPort of the AppSpec exist in the task definition
+func validateEcsTaskDefinitionContainer(ecsLoadBalancerInfo models.LoadBalancerInfo, taskDefinition string) bool {
+	var containerNames []string
+
+	for _, containerDefinition := range ecsTaskDefinition.ContainerDefinitions {
+		containerNames = append(containerNames, containerDefinition.Name)
+		if containerDefinition.Name != ecsLoadBalancerInfo.ContainerName {
+			continue
+		}
+
+		var containerPorts []string
+		for _, portMapping := range containerDefinition.PortMappings {
+			if portMapping.ContainerPort == ecsLoadBalancerInfo.ContainerPort {
+				return true
+			}
+			containerPorts = append(containerPorts, strconv.Itoa(portMapping.ContainerPort))
+		}
+
+		numOfErrors++
+		fmt.Printf(errorHandling.MissingEcsTaskDefinitionContainerPortErr, containerPorts, taskDefinition)
+		return false
+	}
+
+	numOfErrors++
+	fmt.Printf(errorHandling.MissingEcsTaskDefinitionContainerErr, containerNames, taskDefinition)
+
+	return false
+}
+
+// An AwsvpcConfiguration only works with task definitions using the awsvpc network mode
+func validateEcsTaskDefinitionNetworkMode(taskDefinition string) bool {
+	if ecsTaskDefinition.NetworkMode != "awsvpc" {
+		numOfErrors++
+		fmt.Printf(errorHandling.InvalidEcsTaskDefinitionNetworkModeErr, getValueOrNotSet(ecsTaskDefinition.NetworkMode), taskDefinition)
+		return false
+	}
+
+	return true
+}
+
+// CodePipeline only updates the image of containers using an <IMAGE1_NAME> style placeholder
+func validateEcsPipelineTaskDefinitionImages(taskDefinition models.EcsTaskDefinitionModel) bool {
+	for _, containerDefinition := range taskDefinition.ContainerDefinitions {
+		if ecsPipelineImagePlaceholderPattern.MatchString(containerDefinition.Image) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+package assistant
+
+import (
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+var testEcsTaskDefinitionJsonString = `{
+  "family": "web",
+  "networkMode": "awsvpc",
+  "requiresCompatibilities": ["FARGATE"],
+  "containerDefinitions": [
+    {"name": "sidecar", "image": "envoy"},
+    {"name": "web", "image": "<IMAGE1_NAME>", "portMappings": [{"containerPort": 80}, {"containerPort": 8080, "protocol": "tcp"}]}
+  ]
+}`
+
+func setTestEcsTaskDefinition(t *testing.T) {
+	taskDefinition, err := getEcsTaskDefinitionObjFromString([]byte(testEcsTaskDefinitionJsonString))
+	if err != nil {
+		t.Fatalf("getEcsTaskDefinitionObjFromString FAILED: %v", err)
+	}
+	ecsTaskDefinition = &taskDefinition
+}
+
+// Test validateEcsTaskDefinitionContainer
+func TestValidateEcsTaskDefinitionContainer(t *testing.T) {
+	setTestEcsTaskDefinition(t)
+	defer func() { ecsTaskDefinition = nil }()
+
+	var tests = []struct {
+		name             string
+		loadBalancerInfo models.LoadBalancerInfo
+		expectedOutput   bool
+	}{
+		{"Container and port exist", models.LoadBalancerInfo{ContainerName: "web", ContainerPort: 8080}, true},
+		{"Container name typo", models.LoadBalancerInfo{ContainerName: "wbe", ContainerPort: 8080}, false},
+		{"Port not mapped", models.LoadBalancerInfo{ContainerName: "web", ContainerPort: 443}, false},
+		{"Container without port mappings", models.LoadBalancerInfo{ContainerName: "sidecar", ContainerPort: 80}, false},
+	}
+
+	for _, test := range tests {
+		if output := validateEcsTaskDefinitionContainer(test.loadBalancerInfo, "web"); output != test.expectedOutput {
+			t.Errorf("The validateEcsTaskDefinitionContainer function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test validateEcsTaskDefinitionNetworkMode
+func TestValidateEcsTaskDefinitionNetworkMode(t *testing.T) {
+	setTestEcsTaskDefinition(t)
+	defer func() { ecsTaskDefinition = nil }()
+
+	if !validateEcsTaskDefinitionNetworkMode("web") {
+		t.Errorf("The validateEcsTaskDefinitionNetworkMode function failed for awsvpc")
+	}
+
+	ecsTaskDefinition.NetworkMode = "bridge"
+	if validateEcsTaskDefinitionNetworkMode("web") {
+		t.Errorf("The validateEcsTaskDefinitionNetworkMode function succeeded but should have failed for bridge")
+	}
+}
+
+// Test validateEcsPipelineTaskDefinitionImages
+func TestValidateEcsPipelineTaskDefinitionImages(t *testing.T) {
+	var tests = []struct {
+		name           string
+		images         []string
+		expectedOutput bool
+	}{
+		{"Placeholder", []string{"envoy", "<IMAGE1_NAME>"}, true},
+		{"Placeholder without number", []string{"<IMAGE_NAME>"}, true},
+		{"No placeholder", []string{"nginx:latest"}, false},
+	}
+
+	for _, test := range tests {
+		var taskDefinition models.EcsTaskDefinitionModel
+		for _, image := range test.images {
+			taskDefinition.ContainerDefinitions = append(taskDefinition.ContainerDefinitions, models.ContainerDefinition{Image: image})
+		}
+		if output := validateEcsPipelineTaskDefinitionImages(taskDefinition); output != test.expectedOutput {
+			t.Errorf("The validateEcsPipelineTaskDefinitionImages function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test validateEcsResourceProperties in pipeline mode
+func TestValidateEcsResourceProperties_PipelineMode(t *testing.T) {
+	EcsPipelineMode = true
+	setTestEcsTaskDefinition(t)
+	defer func() {
+		EcsPipelineMode = false
+		ecsTaskDefinition = nil
+	}()
+
+	var tests = []struct {
+		name           string
+		taskDefinition string
+		expectedOutput bool
+	}{
+		{"Placeholder", "<TASK_DEFINITION>", true},
+		{"ARN instead of placeholder", "arn:aws:ecs:us-east-1:111122223333:task-definition/web:3", false},
+	}
+
+	for _, test := range tests {
+		properties := models.EcsProperties{
+			TaskDefinition:   test.taskDefinition,
+			LoadBalancerInfo: models.LoadBalancerInfo{ContainerName: "web", ContainerPort: 80},
+		}
+		if output := validateEcsResourceProperties(properties); output != test.expectedOutput {
+			t.Errorf("The validateEcsResourceProperties function returned %v in pipeline mode for: %v", output, test.name)
+		}
+	}
+
+	if isAppSpecPlaceholder("<TASK_DEFINITION>") {
+		t.Errorf("The isAppSpecPlaceholder function reported <TASK_DEFINITION> in pipeline mode")
+	}
+}
+
+// Test getEcsTaskDefinitionObjFromString
+func TestGetEcsTaskDefinitionObjFromString_DescribeOutput(t *testing.T) {
+	taskDefinition, err := getEcsTaskDefinitionObjFromString([]byte(`{"taskDefinition": {"taskDefinitionArn": "arn:aws:ecs:us-east-1:111122223333:task-definition/web:7", "family": "web", "revision": 7, "containerDefinitions": [{"name": "web"}]}}`))
+	if err != nil || taskDefinition.Family != "web" || taskDefinition.Revision != 7 || len(taskDefinition.ContainerDefinitions) != 1 {
+		t.Errorf("The getEcsTaskDefinitionObjFromString function did not unwrap the describe-task-definition output. Got: %v %v", taskDefinition, err)
+	}
+}
+
+// Test getEcsTaskDefinitionFamilyAndRevision
+func TestGetEcsTaskDefinitionFamilyAndRevision(t *testing.T) {
+	var tests = []struct {
+		taskDefinition   string
+		expectedFamily   string
+		expectedRevision int
+	}{
+		{"arn:aws:ecs:us-east-1:111122223333:task-definition/web:7", "web", 7},
+		{"arn:aws-cn:ecs:cn-north-1:111122223333:task-definition/web-app_2:12", "web-app_2", 12},
+		{"web:3", "web", 3},
+		{"web", "web", 0},
+	}
+
+	for _, test := range tests {
+		family, revision := getEcsTaskDefinitionFamilyAndRevision(test.taskDefinition)
+		if family != test.expectedFamily || revision != test.expectedRevision {
+			t.Errorf("The getEcsTaskDefinitionFamilyAndRevision function returned %v, %v for: %v", family, revision, test.taskDefinition)
+		}
+	}
+}
+
+// Test validateEcsTaskDefinitionIdentifier
+func TestValidateEcsTaskDefinitionIdentifier(t *testing.T) {
+	ecsTaskDefinition = &models.EcsTaskDefinitionModel{TaskDefinitionArn: "arn:aws:ecs:us-east-1:111122223333:task-definition/web:7"}
+	defer func() { ecsTaskDefinition = nil }()
+
+	var tests = []struct {
+		taskDefinition string
+		expectedOutput bool
+	}{
+		{"arn:aws:ecs:us-east-1:111122223333:task-definition/web:7", true},
+		{"web:7", true},
+		{"web", true},
+		{"<TASK_DEFINITION>", true},
+		{"arn:aws:ecs:us-east-1:111122223333:task-definition/web:6", false},
+		{"api:7", false},
+	}
+
+	for _, test := range tests {
+		if output := validateEcsTaskDefinitionIdentifier(test.taskDefinition); output != test.expectedOutput {
+			t.Errorf("The validateEcsTaskDefinitionIdentifier function returned %v for: %v", output, test.taskDefinition)
+		}
+	}
+}
+
+// Test validateEcsTaskDefinitionCompatibilities
+func TestValidateEcsTaskDefinitionCompatibilities(t *testing.T) {
+	var tests = []struct {
+		name                    string
+		requiresCompatibilities []string
+		platformVersion         string
+		expectedOutput          bool
+	}{
+		{"Fargate", []string{"EC2", "FARGATE"}, "1.4.0", true},
+		{"EC2 only without PlatformVersion", []string{"EC2"}, "", true},
+		{"EC2 only with PlatformVersion", []string{"EC2"}, "LATEST", false},
+	}
+
+	for _, test := range tests {
+		ecsTaskDefinition = &models.EcsTaskDefinitionModel{RequiresCompatibilities: test.requiresCompatibilities}
+		if output := validateEcsTaskDefinitionCompatibilities(test.platformVersion, "web"); output != test.expectedOutput {
+			t.Errorf("The validateEcsTaskDefinitionCompatibilities function returned %v for: %v", output, test.name)
+		}
+	}
+	ecsTaskDefinition = nil
+}
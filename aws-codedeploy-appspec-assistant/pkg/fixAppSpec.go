@@ -0,0 +1,335 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+)
+
+// Set by the validate command
+// FixAppSpec writes the fixed AppSpec back to the file
+// PreviewAppSpecFixes only prints a diff of the fixes
+var FixAppSpec bool
+var PreviewAppSpecFixes bool
+
+// Apply the automatic fixes to the AppSpec file
+// Returns the AppSpec content that should be validated afterwards
+func applyAppSpecFixes(filePath string, appSpecBytes []byte, computePlatform string) ([]byte, error) {
+	fixedAppSpecBytes, fixes, err := fixAppSpecString(appSpecBytes, computePlatform)
+	if err != nil {
+		return appSpecBytes, err
+	}
+
+	if len(fixes) < 1 {
+		fmt.Println(errorHandling.NoAppSpecFixesMsg)
+		return appSpecBytes, nil
+	}
+
+	for _, fix := range fixes {
+		fmt.Println("FIX: " + fix)
+	}
+
+	if PreviewAppSpecFixes {
+		fmt.Println()
+		fmt.Print(getLineDiff(filePath, string(appSpecBytes), string(fixedAppSpecBytes)))
+		fmt.Println(errorHandling.PreviewAppSpecFixesMsg)
+		return appSpecBytes, nil
+	}
+
+	if err := ioutil.WriteFile(filePath, fixedAppSpecBytes, 0644); err != nil {
+		return appSpecBytes, err
+	}
+	fmt.Printf(errorHandling.WroteAppSpecFixesMsg, len(fixes), filePath)
+
+	return fixedAppSpecBytes, nil
+}
+
+// A fix applied to a scalar of the YAML node tree
+// oldText and newText are how the scalar is written in the AppSpec file
+type appSpecFix struct {
+	description string
+	line        int
+	column      int
+	oldText     string
+	newText     string
+}
+
+// Fix the mechanically correctable findings of the validators on the YAML node tree
+// Returns the fixed AppSpec string and a description of every fix
+// The original string is returned untouched if there is nothing to fix
+func fixAppSpecString(appSpecBytes []byte, computePlatform string) ([]byte, []string, error) {
+	appSpecNode, err := getAppSpecNodeFromString(appSpecBytes)
+	if err != nil {
+		return appSpecBytes, nil, err
+	}
+
+	var fixes []appSpecFix
+	rootNode := appSpecNode.Content[0]
+
+	if computePlatform == "ecs" {
+		fixes = fixEcsAppSpecNode(rootNode)
+	} else if computePlatform == "lambda" {
+		fixes = fixLambdaAppSpecNode(rootNode)
+	} else {
+		fixes = fixServerAppSpecNode(rootNode)
+	}
+
+	if len(fixes) < 1 {
+		return appSpecBytes, nil, nil
+	}
+
+	var fixDescriptions []string
+	for _, fix := range fixes {
+		fixDescriptions = append(fixDescriptions, fix.description)
+	}
+
+	// Only the fixed scalars are replaced in the original string so formatting is kept as is
+	// Fall back to writing out the whole node tree if a scalar cannot be found where the parser saw it
+	if fixedAppSpecBytes, ok := applyAppSpecFixesToString(appSpecBytes, fixes); ok {
+		return fixedAppSpecBytes, fixDescriptions, nil
+	}
+
+	fixedAppSpecBytes, err := getStringFromAppSpecNode(appSpecNode)
+
+	return fixedAppSpecBytes, fixDescriptions, err
+}
+
+func applyAppSpecFixesToString(appSpecBytes []byte, fixes []appSpecFix) ([]byte, bool) {
+	lines := strings.Split(string(appSpecBytes), "\n")
+
+	// Apply from the end of the file so earlier positions stay valid
+	sort.Slice(fixes, func(i, j int) bool {
+		if fixes[i].line != fixes[j].line {
+			return fixes[i].line > fixes[j].line
+		}
+		return fixes[i].column > fixes[j].column
+	})
+
+	for _, fix := range fixes {
+		if fix.line < 1 || fix.line > len(lines) {
+			return nil, false
+		}
+		line := []rune(lines[fix.line-1])
+		start := fix.column - 1
+		end := start + len([]rune(fix.oldText))
+		if start < 0 || end > len(line) || string(line[start:end]) != fix.oldText {
+			return nil, false
+		}
+		lines[fix.line-1] = string(line[:start]) + fix.newText + string(line[end:])
+	}
+
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+func fixEcsAppSpecNode(rootNode *yaml.Node) []appSpecFix {
+	var fixes []appSpecFix
+
+	if resourcesNode := getMappingValueNode(rootNode, "Resources"); resourcesNode != nil {
+		for _, resourceNode := range resourcesNode.Content {
+			targetServiceNode := getMappingValueNode(resourceNode, "TargetService")
+			fixes = append(fixes, fixScalarCase(getMappingValueNode(targetServiceNode, "Type"), "Type", []string{"AWS::ECS::Service"})...)
+
+			propertiesNode := getMappingValueNode(targetServiceNode, "Properties")
+			networkConfigNode := getMappingValueNode(propertiesNode, "NetworkConfiguration")
+			awsvpcConfigNode := getMappingValueNode(networkConfigNode, "AwsvpcConfiguration")
+
+			fixes = append(fixes, fixScalarCase(getMappingValueNode(awsvpcConfigNode, "AssignPublicIp"), "AssignPublicIp", globalVars.AppSpecEcsAssignPublicIpValues[:])...)
+			fixes = append(fixes, fixSequenceWhitespace(getMappingValueNode(awsvpcConfigNode, "Subnets"), "Subnets")...)
+			fixes = append(fixes, fixSequenceWhitespace(getMappingValueNode(awsvpcConfigNode, "SecurityGroups"), "SecurityGroups")...)
+		}
+	}
+
+	fixes = append(fixes, fixHookListKeys(getMappingValueNode(rootNode, "Hooks"), globalVars.AppSpecSupportedEcsHooks[:])...)
+
+	return fixes
+}
+
+func fixLambdaAppSpecNode(rootNode *yaml.Node) []appSpecFix {
+	var fixes []appSpecFix
+
+	if resourcesNode := getMappingValueNode(rootNode, "Resources"); resourcesNode != nil {
+		for _, resourceNode := range resourcesNode.Content {
+			if resourceNode.Kind != yaml.MappingNode {
+				continue
+			}
+			for i := 1; i < len(resourceNode.Content); i += 2 {
+				functionNode := resourceNode.Content[i]
+				fixes = append(fixes, fixScalarCase(getMappingValueNode(functionNode, "Type"), "Type", []string{"AWS::Lambda::Function"})...)
+
+				// JSON numbers cannot be converted to the string versions of the model
+				if fileExtension != "yml" {
+					propertiesNode := getMappingValueNode(functionNode, "Properties")
+					fixes = append(fixes, fixNumericScalar(getMappingValueNode(propertiesNode, "CurrentVersion"), "CurrentVersion")...)
+					fixes = append(fixes, fixNumericScalar(getMappingValueNode(propertiesNode, "TargetVersion"), "TargetVersion")...)
+				}
+			}
+		}
+	}
+
+	fixes = append(fixes, fixHookListKeys(getMappingValueNode(rootNode, "Hooks"), globalVars.AppSpecSupportedLambdaHooks[:])...)
+
+	return fixes
+}
+
+func fixServerAppSpecNode(rootNode *yaml.Node) []appSpecFix {
+	var fixes []appSpecFix
+
+	fixes = append(fixes, fixScalarCase(getMappingValueNode(rootNode, "os"), "os", globalVars.AppSpecSupportedServerOSs[:])...)
+
+	var supportedHooks []string
+	supportedHooks = append(supportedHooks, globalVars.AppSpecSupportedServerHooksWithoutLB[:]...)
+	supportedHooks = append(supportedHooks, globalVars.AppSpecSupportedServerHooksWithLB[:]...)
+
+	if hooksNode := getMappingValueNode(rootNode, "hooks"); hooksNode != nil && hooksNode.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(hooksNode.Content); i += 2 {
+			fixes = append(fixes, fixScalarCase(hooksNode.Content[i], "hook", supportedHooks)...)
+		}
+	}
+
+	return fixes
+}
+
+// Replace a scalar with the supported value it matches ignoring case
+func fixScalarCase(scalarNode *yaml.Node, name string, supportedValues []string) []appSpecFix {
+	if scalarNode == nil || scalarNode.Kind != yaml.ScalarNode {
+		return nil
+	}
+
+	for _, supportedValue := range supportedValues {
+		if scalarNode.Value != supportedValue && strings.EqualFold(scalarNode.Value, supportedValue) {
+			return []appSpecFix{setFixedScalar(scalarNode, name, supportedValue, scalarNode.Style)}
+		}
+	}
+
+	return nil
+}
+
+// Trim the whitespace around every string in a sequence
+func fixSequenceWhitespace(sequenceNode *yaml.Node, name string) []appSpecFix {
+	var fixes []appSpecFix
+
+	if sequenceNode == nil || sequenceNode.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, itemNode := range sequenceNode.Content {
+		if itemNode.Kind == yaml.ScalarNode && itemNode.Value != strings.TrimSpace(itemNode.Value) {
+			fixes = append(fixes, setFixedScalar(itemNode, name, strings.TrimSpace(itemNode.Value), itemNode.Style))
+		}
+	}
+
+	return fixes
+}
+
+// Fix the hook names of ECS and Lambda AppSpecs ([{<Hook>: <Function>}, ...])
+func fixHookListKeys(hooksNode *yaml.Node, supportedHooks []string) []appSpecFix {
+	var fixes []appSpecFix
+
+	if hooksNode == nil || hooksNode.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, hookNode := range hooksNode.Content {
+		if hookNode.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(hookNode.Content); i += 2 {
+			fixes = append(fixes, fixScalarCase(hookNode.Content[i], "hook", supportedHooks)...)
+		}
+	}
+
+	return fixes
+}
+
+// Turn a number into a string ("CurrentVersion": 1 -> "CurrentVersion": "1")
+func fixNumericScalar(scalarNode *yaml.Node, name string) []appSpecFix {
+	if scalarNode == nil || scalarNode.Kind != yaml.ScalarNode {
+		return nil
+	}
+
+	if scalarNode.ShortTag() != "!!int" && scalarNode.ShortTag() != "!!float" {
+		return nil
+	}
+
+	scalarNode.Tag = "!!str"
+
+	return []appSpecFix{setFixedScalar(scalarNode, name, scalarNode.Value, yaml.DoubleQuotedStyle)}
+}
+
+// Update a scalar node and record how it changes in the AppSpec file
+func setFixedScalar(scalarNode *yaml.Node, name string, value string, style yaml.Style) appSpecFix {
+	fix := appSpecFix{
+		line:    scalarNode.Line,
+		column:  scalarNode.Column,
+		oldText: getScalarText(scalarNode.Value, scalarNode.Style),
+		newText: getScalarText(value, style),
+	}
+	fix.description = fmt.Sprintf("line %d: %s %s -> %s", fix.line, name, fix.oldText, fix.newText)
+
+	scalarNode.Value = value
+	scalarNode.Style = style
+
+	return fix
+}
+
+func getScalarText(value string, style yaml.Style) string {
+	switch style {
+	case yaml.DoubleQuotedStyle:
+		return "\"" + value + "\""
+	case yaml.SingleQuotedStyle:
+		return "'" + value + "'"
+	}
+
+	return value
+}
+
+// Build a line diff of two strings
+// AppSpecs are short, so every line is printed with unchanged lines as context
+func getLineDiff(filePath string, oldStr string, newStr string) string {
+	oldLines := strings.Split(strings.TrimSuffix(oldStr, "\n"), "\n")
+	newLines := strings.Split(strings.TrimSuffix(newStr, "\n"), "\n")
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- " + filePath + "\n")
+	sb.WriteString("+++ " + filePath + " (fixed)\n")
+
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		if i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j] {
+			sb.WriteString("  " + oldLines[i] + "\n")
+			i++
+			j++
+		} else if i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]) {
+			sb.WriteString("- " + oldLines[i] + "\n")
+			i++
+		} else {
+			sb.WriteString("+ " + newLines[j] + "\n")
+			j++
+		}
+	}
+
+	return sb.String()
+}
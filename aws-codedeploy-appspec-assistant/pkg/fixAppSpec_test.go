@@ -0,0 +1,78 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test fixAppSpecString
+func TestFixAppSpecString_FixableInput(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		appSpecInput         string
+		computePlatformInput string
+		fileExtensionVal     string
+		expectedOutput       string
+		expectedNumOfFixes   int
+	}{
+		{"ECS Type, AssignPublicIp, Subnets, and Hooks",
+			"version: 0.0\nResources:\n  - TargetService:\n      Type: AWS::ECS::service # comment\n      Properties:\n        NetworkConfiguration:\n          AwsvpcConfiguration:\n            Subnets: [\"subnet-1 \"]\n            SecurityGroups:\n              - \" sg-1\"\n            AssignPublicIp: enabled\nHooks:\n  - beforeInstall: \"fn\"\n",
+			"ecs", "yml",
+			"version: 0.0\nResources:\n  - TargetService:\n      Type: AWS::ECS::Service # comment\n      Properties:\n        NetworkConfiguration:\n          AwsvpcConfiguration:\n            Subnets: [\"subnet-1\"]\n            SecurityGroups:\n              - \"sg-1\"\n            AssignPublicIp: ENABLED\nHooks:\n  - BeforeInstall: \"fn\"\n",
+			5},
+
+		{"Lambda JSON numeric versions",
+			"{\n  \"version\": 0.0,\n  \"Resources\": [{\"f\": {\"Type\": \"AWS::LAMBDA::FUNCTION\", \"Properties\": {\"CurrentVersion\": 1, \"TargetVersion\": 2}}}]\n}",
+			"lambda", "json",
+			"{\n  \"version\": 0.0,\n  \"Resources\": [{\"f\": {\"Type\": \"AWS::Lambda::Function\", \"Properties\": {\"CurrentVersion\": \"1\", \"TargetVersion\": \"2\"}}}]\n}",
+			3},
+
+		{"Server OS and hooks",
+			"version: 0.0\nos: Linux\nhooks:\n  afterinstall:\n    - location: scripts/a.sh\n",
+			"server", "yml",
+			"version: 0.0\nos: linux\nhooks:\n  AfterInstall:\n    - location: scripts/a.sh\n",
+			2},
+	}
+
+	for _, test := range tests {
+		fileExtension = test.fileExtensionVal
+		output, fixes, err := fixAppSpecString([]byte(test.appSpecInput), test.computePlatformInput)
+		if err != nil || string(output) != test.expectedOutput || len(fixes) != test.expectedNumOfFixes {
+			t.Errorf("The fixAppSpecString function did not fix the AppSpec correctly for: %v. Got: %v %v %v", test.name, string(output), fixes, err)
+		}
+	}
+}
+
+func TestFixAppSpecString_NothingToFixInput(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		appSpecInput         string
+		computePlatformInput string
+		fileExtensionVal     string
+	}{
+		{"Valid ECS YAML",
+			ecsYamlString, "ecs", "yml"},
+		{"Valid Lambda JSON",
+			lambdaJsonString, "lambda", "json"},
+		{"Lambda YAML numeric versions are left as they are",
+			"version: 0.0\nResources:\n  - f:\n      Properties:\n        CurrentVersion: 1\n", "lambda", "yml"},
+		{"Unknown values are not guessed",
+			"version: 0.0\nos: linuxx\n", "server", "yml"},
+	}
+
+	for _, test := range tests {
+		fileExtension = test.fileExtensionVal
+		output, fixes, err := fixAppSpecString([]byte(test.appSpecInput), test.computePlatformInput)
+		if err != nil || string(output) != test.appSpecInput || len(fixes) != 0 {
+			t.Errorf("The fixAppSpecString function changed an AppSpec without fixable findings for: %v. Got: %v", test.name, fixes)
+		}
+	}
+}
+
+// Test getLineDiff
+func TestGetLineDiff(t *testing.T) {
+	output := getLineDiff("appspec.yml", "version: 0.0\nos: Linux\n", "version: 0.0\nos: linux\n")
+	if !strings.Contains(output, "- os: Linux\n+ os: linux\n") || !strings.Contains(output, "  version: 0.0\n") {
+		t.Errorf("The getLineDiff function did not build the expected diff. Got: %v", output)
+	}
+}
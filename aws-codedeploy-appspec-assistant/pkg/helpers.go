@@ -0,0 +1,91 @@
+package assistant
+
+import (
+	"sort"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Sorted keys of maps combined, so reports and messages do not change order between runs
+// One helper per map type, a nil map has no keys
+
+func getSortedHookKeys(hookMaps ...map[string][]models.Hook) []string {
+	keySet := map[string]bool{}
+	for _, hookMap := range hookMaps {
+		for key := range hookMap {
+			keySet[key] = true
+		}
+	}
+
+	return getSortedKeySet(keySet)
+}
+
+func getSortedStringListKeys(stringListMaps ...map[string][]string) []string {
+	keySet := map[string]bool{}
+	for _, stringListMap := range stringListMaps {
+		for key := range stringListMap {
+			keySet[key] = true
+		}
+	}
+
+	return getSortedKeySet(keySet)
+}
+
+func getSortedFunctionKeys(functionMaps ...map[string]models.Function) []string {
+	keySet := map[string]bool{}
+	for _, functionMap := range functionMaps {
+		for key := range functionMap {
+			keySet[key] = true
+		}
+	}
+
+	return getSortedKeySet(keySet)
+}
+
+func getSortedPermissionKeys(permissionMaps ...map[string]models.Permission) []string {
+	keySet := map[string]bool{}
+	for _, permissionMap := range permissionMaps {
+		for key := range permissionMap {
+			keySet[key] = true
+		}
+	}
+
+	return getSortedKeySet(keySet)
+}
+
+func getSortedLambdaInventoryKeys(inventory map[string]*lambdaFunctionInventory) []string {
+	keySet := map[string]bool{}
+	for key := range inventory {
+		keySet[key] = true
+	}
+
+	return getSortedKeySet(keySet)
+}
+
+func getSortedLambdaAliasKeys(aliases map[string]models.LambdaFunctionAlias) []string {
+	keySet := map[string]bool{}
+	for key := range aliases {
+		keySet[key] = true
+	}
+
+	return getSortedKeySet(keySet)
+}
+
+func getSortedKeySet(keySet map[string]bool) []string {
+	var keys []string
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Empty values as "(not set)" in messages
+func getValueOrNotSet(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+
+	return value
+}
@@ -0,0 +1,41 @@
+package assistant
+
+import (
+	"reflect"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Test the getSorted*Keys helpers
+func TestGetSortedKeys(t *testing.T) {
+	var tests = []struct {
+		name     string
+		output   []string
+		expected []string
+	}{
+		{"Two function maps", getSortedFunctionKeys(map[string]models.Function{"b": {}, "a": {}}, map[string]models.Function{"c": {}, "a": {}}), []string{"a", "b", "c"}},
+		{"Hook map and nil", getSortedHookKeys(map[string][]models.Hook{"BeforeInstall": nil, "AfterInstall": nil}, nil), []string{"AfterInstall", "BeforeInstall"}},
+		{"String list map", getSortedStringListKeys(map[string][]string{"z": nil, "y": {"a"}}), []string{"y", "z"}},
+		{"Permission maps", getSortedPermissionKeys(map[string]models.Permission{"/var/www": {}}, map[string]models.Permission{"/etc/app": {}}), []string{"/etc/app", "/var/www"}},
+		{"Lambda aliases", getSortedLambdaAliasKeys(map[string]models.LambdaFunctionAlias{"live": {}, "beta": {}}), []string{"beta", "live"}},
+		{"No maps", getSortedHookKeys(), nil},
+		{"Nil inventory", getSortedLambdaInventoryKeys(nil), nil},
+	}
+
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.output, test.expected) {
+			t.Errorf("The sorted keys were %v but should have been %v for: %v", test.output, test.expected, test.name)
+		}
+	}
+}
+
+// Test getValueOrNotSet
+func TestGetValueOrNotSet(t *testing.T) {
+	if output := getValueOrNotSet(""); output != "(not set)" {
+		t.Errorf("The getValueOrNotSet function returned %v for an empty value", output)
+	}
+	if output := getValueOrNotSet("awsvpc"); output != "awsvpc" {
+		t.Errorf("The getValueOrNotSet function returned %v for awsvpc", output)
+	}
+}
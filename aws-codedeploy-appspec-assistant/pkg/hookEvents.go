@@ -0,0 +1,193 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Name of the generated script that invokes every hook function with its event
+const hookEventsHarnessFileName = "run-hook-events.sh"
+
+// A hook of the AppSpec with the event CodeDeploy would invoke its function with
+type lifecycleHookEvent struct {
+	hook          appSpecHook
+	event         models.LifecycleHookEventModel
+	eventFileName string
+}
+
+// Main function of the hook-events command
+func GenerateHookEvents(filePath string, computePlatform string, outDirPath string) {
+	fmt.Println("generateHookEvents called on:", filePath, ",", computePlatform)
+
+	appSpecBytes, err := loadAppSpecFile(filePath, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	hookEvents, err := getLifecycleHookEvents(appSpecBytes, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := writeLifecycleHookEvents(hookEvents, filePath, outDirPath); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	for _, hookEvent := range hookEvents {
+		fmt.Printf("%s: %s -> %s\n", hookEvent.hook.name, hookEvent.hook.function, filepath.Join(outDirPath, hookEvent.eventFileName))
+	}
+	fmt.Printf(errorHandling.WroteHookEventsMsg, len(hookEvents), filepath.Join(outDirPath, hookEventsHarnessFileName))
+}
+
+// Get the hooks of an ECS or Lambda AppSpec in the order CodeDeploy runs them, each with a sample event
+// The events only depend on the AppSpec content so they do not change between runs
+func getLifecycleHookEvents(appSpecBytes []byte, computePlatform string) ([]lifecycleHookEvent, error) {
+	var hooks []map[string]string
+	var supportedHooks []string
+
+	switch computePlatform {
+	case "ecs":
+		ecsAppSpecModel, err := getEcsAppSpecObjFromString(appSpecBytes)
+		if err != nil {
+			return nil, err
+		}
+		if !validateEcsHooks(ecsAppSpecModel.Hooks) {
+			return nil, fmt.Errorf(errorHandling.InvalidECSHooksAndFunctionsErr)
+		}
+		hooks, supportedHooks = ecsAppSpecModel.Hooks, globalVars.AppSpecSupportedEcsHooks[:]
+	case "lambda":
+		lambdaAppSpecModel, err := getLambdaAppSpecObjFromString(appSpecBytes)
+		if err != nil {
+			return nil, err
+		}
+		if !validateLambdaHooks(lambdaAppSpecModel.Hooks) {
+			return nil, fmt.Errorf(errorHandling.InvalidLambdaHooksErr, globalVars.AppSpecSupportedLambdaHooks)
+		}
+		hooks, supportedHooks = lambdaAppSpecModel.Hooks, globalVars.AppSpecSupportedLambdaHooks[:]
+	default:
+		return nil, fmt.Errorf(errorHandling.UnsupportedHookEventsComputePlatformErr)
+	}
+
+	if len(hooks) < 1 {
+		return nil, fmt.Errorf(errorHandling.MissingHooksForHookEventsErr)
+	}
+
+	deploymentId := getSampleDeploymentId(appSpecBytes)
+
+	var hookEvents []lifecycleHookEvent
+	for _, hook := range getLifecycleOrderedHooks(getAppSpecHookList(hooks), supportedHooks) {
+		hookEvents = append(hookEvents, lifecycleHookEvent{
+			hook: hook,
+			event: models.LifecycleHookEventModel{
+				DeploymentId:                  deploymentId,
+				LifecycleEventHookExecutionId: getSampleLifecycleEventHookExecutionId(deploymentId, hook.name),
+			},
+			eventFileName: fmt.Sprintf("%02d-%s.json", len(hookEvents)+1, hook.name),
+		})
+	}
+
+	return hookEvents, nil
+}
+
+// Sort the hooks of the AppSpec by the lifecycle order of the supported hooks
+func getLifecycleOrderedHooks(hooks []appSpecHook, lifecycleOrder []string) []appSpecHook {
+	var orderedHooks []appSpecHook
+
+	for _, lifecycleEvent := range lifecycleOrder {
+		for _, hook := range hooks {
+			if hook.name == lifecycleEvent {
+				orderedHooks = append(orderedHooks, hook)
+			}
+		}
+	}
+
+	return orderedHooks
+}
+
+// Deployment IDs look like d-A1B2C3D4E
+func getSampleDeploymentId(appSpecBytes []byte) string {
+	const deploymentIdChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	appSpecHash := sha256.Sum256(appSpecBytes)
+
+	deploymentId := "d-"
+	for _, hashByte := range appSpecHash[:9] {
+		deploymentId += string(deploymentIdChars[int(hashByte)%len(deploymentIdChars)])
+	}
+
+	return deploymentId
+}
+
+// Execution IDs are opaque base64 encoded tokens
+func getSampleLifecycleEventHookExecutionId(deploymentId string, hook string) string {
+	executionHash := sha256.Sum256([]byte(deploymentId + "/" + hook))
+
+	executionToken := fmt.Sprintf(`{"encryptedData":"%s","ivParameterSpec":"%s","materialSetSerial":1}`,
+		base64.StdEncoding.EncodeToString(executionHash[:]), base64.StdEncoding.EncodeToString(executionHash[:12]))
+
+	return base64.StdEncoding.EncodeToString([]byte(executionToken))
+}
+
+// Write an event file per hook and the harness script into the out directory
+func writeLifecycleHookEvents(hookEvents []lifecycleHookEvent, appSpecFilePath string, outDirPath string) error {
+	if err := os.MkdirAll(outDirPath, 0755); err != nil {
+		return err
+	}
+
+	for _, hookEvent := range hookEvents {
+		eventBytes, err := json.MarshalIndent(hookEvent.event, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(outDirPath, hookEvent.eventFileName), append(eventBytes, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+
+	harness := getHookEventsHarness(hookEvents, appSpecFilePath)
+
+	return ioutil.WriteFile(filepath.Join(outDirPath, hookEventsHarnessFileName), []byte(harness), 0755)
+}
+
+// Shell script that invokes every hook function with its event in lifecycle order
+// Defaults to sam local invoke, INVOKE_CMD replaces it and is called with the function and the event file
+func getHookEventsHarness(hookEvents []lifecycleHookEvent, appSpecFilePath string) string {
+	var harness strings.Builder
+
+	harness.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&harness, "# Generated by appSpecAssistant hook-events from %s\n", strings.Replace(appSpecFilePath, "\n", " ", -1))
+	harness.WriteString("# Invokes the hook functions of the AppSpec in lifecycle order with their sample events\n")
+	harness.WriteString("# The functions must be resources of the SAM template, or set INVOKE_CMD (called with the function and the event file)\n")
+	harness.WriteString("set -e\n\n")
+	harness.WriteString("cd \"$(dirname \"$0\")\"\n\n")
+	harness.WriteString("invoke_hook() {\n")
+	harness.WriteString("  echo \"==> $1 ($2)\"\n")
+	harness.WriteString("  if [ -n \"$INVOKE_CMD\" ]; then\n")
+	harness.WriteString("    $INVOKE_CMD \"$2\" \"$3\"\n")
+	harness.WriteString("  else\n")
+	harness.WriteString("    sam local invoke \"$2\" --event \"$3\"\n")
+	harness.WriteString("  fi\n")
+	harness.WriteString("}\n\n")
+
+	for _, hookEvent := range hookEvents {
+		fmt.Fprintf(&harness, "invoke_hook %s %s %s\n", quoteShellArg(hookEvent.hook.name), quoteShellArg(getLambdaFunctionName(hookEvent.hook.function)), quoteShellArg(hookEvent.eventFileName))
+	}
+
+	return harness.String()
+}
+
+// Single quote a value for sh, a quote in it ends the quoting, adds an escaped quote, and starts it again
+func quoteShellArg(value string) string {
+	return "'" + strings.Replace(value, "'", "'\\''", -1) + "'"
+}
@@ -0,0 +1,123 @@
+package assistant
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var testHookEventsEcsYamlString = `version: 0.0
+Resources:
+  - TargetService:
+      Type: AWS::ECS::Service
+      Properties:
+        TaskDefinition: "web:3"
+        LoadBalancerInfo:
+          ContainerName: "web"
+          ContainerPort: 8080
+Hooks:
+  - AfterAllowTraffic: "CodeDeployHook_AfterAllowTraffic"
+  - BeforeInstall: "arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeInstall:live"
+  - AfterAllowTestTraffic: "CodeDeployHook_AfterAllowTestTraffic"`
+
+// Test getLifecycleHookEvents
+func TestGetLifecycleHookEvents_Ecs(t *testing.T) {
+	fileExtension = "yml"
+
+	hookEvents, err := getLifecycleHookEvents([]byte(testHookEventsEcsYamlString), "ecs")
+	if err != nil {
+		t.Fatalf("The getLifecycleHookEvents function failed: %v", err)
+	}
+
+	var hookNames []string
+	for _, hookEvent := range hookEvents {
+		hookNames = append(hookNames, hookEvent.hook.name)
+	}
+	if strings.Join(hookNames, ",") != "BeforeInstall,AfterAllowTestTraffic,AfterAllowTraffic" {
+		t.Errorf("The getLifecycleHookEvents function did not return the hooks in lifecycle order. Got: %v", hookNames)
+	}
+
+	if hookEvents[0].eventFileName != "01-BeforeInstall.json" {
+		t.Errorf("The getLifecycleHookEvents function returned the event file name %v", hookEvents[0].eventFileName)
+	}
+
+	if !regexp.MustCompile(`^d-[0-9A-Z]{9}$`).MatchString(hookEvents[0].event.DeploymentId) {
+		t.Errorf("The getLifecycleHookEvents function returned the malformed DeploymentId %v", hookEvents[0].event.DeploymentId)
+	}
+
+	if hookEvents[0].event.LifecycleEventHookExecutionId == hookEvents[1].event.LifecycleEventHookExecutionId {
+		t.Errorf("The getLifecycleHookEvents function returned the same LifecycleEventHookExecutionId for two hooks")
+	}
+
+	// Same AppSpec, same events
+	sameHookEvents, _ := getLifecycleHookEvents([]byte(testHookEventsEcsYamlString), "ecs")
+	if sameHookEvents[2].event != hookEvents[2].event {
+		t.Errorf("The getLifecycleHookEvents function is not deterministic: %v != %v", sameHookEvents[2].event, hookEvents[2].event)
+	}
+}
+
+func TestGetLifecycleHookEvents_InvalidInput(t *testing.T) {
+	var tests = []struct {
+		name            string
+		appSpecString   string
+		computePlatform string
+	}{
+		{"Server AppSpec", serverYamlString, "server"},
+		{"No hooks", "version: 0.0\nResources: []\n", "lambda"},
+		{"Unsupported hook", "version: 0.0\nHooks:\n  - BeforeInstall: CodeDeployHook_BeforeInstall\n", "lambda"},
+	}
+
+	fileExtension = "yml"
+	for _, test := range tests {
+		if _, err := getLifecycleHookEvents([]byte(test.appSpecString), test.computePlatform); err == nil {
+			t.Errorf("The getLifecycleHookEvents function succeeded but should have failed for: %v", test.name)
+		}
+	}
+}
+
+// Test writeLifecycleHookEvents
+func TestWriteLifecycleHookEvents(t *testing.T) {
+	outDirPath, err := ioutil.TempDir("", "hook-events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outDirPath)
+
+	fileExtension = "yml"
+	hookEvents, _ := getLifecycleHookEvents([]byte(testHookEventsEcsYamlString), "ecs")
+	if err := writeLifecycleHookEvents(hookEvents, "appspec.yml", outDirPath); err != nil {
+		t.Fatalf("The writeLifecycleHookEvents function failed: %v", err)
+	}
+
+	eventBytes, err := ioutil.ReadFile(filepath.Join(outDirPath, "01-BeforeInstall.json"))
+	if err != nil || !strings.Contains(string(eventBytes), `"DeploymentId": "`+hookEvents[0].event.DeploymentId+`"`) {
+		t.Errorf("The writeLifecycleHookEvents function did not write the BeforeInstall event. Got: %s %v", eventBytes, err)
+	}
+
+	harnessBytes, err := ioutil.ReadFile(filepath.Join(outDirPath, hookEventsHarnessFileName))
+	if err != nil || !strings.Contains(string(harnessBytes), "invoke_hook 'BeforeInstall' 'CodeDeployHook_BeforeInstall' '01-BeforeInstall.json'\n") {
+		t.Errorf("The writeLifecycleHookEvents function did not write the harness. Got: %s %v", harnessBytes, err)
+	}
+}
+
+// Test quoteShellArg
+func TestQuoteShellArg(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected string
+	}{
+		{"CodeDeployHook_BeforeInstall", "'CodeDeployHook_BeforeInstall'"},
+		{"", "''"},
+		{"it's", "'it'\\''s'"},
+		{"$(rm -rf /); `id`", "'$(rm -rf /); `id`'"},
+	}
+
+	for _, test := range tests {
+		if output := quoteShellArg(test.value); output != test.expected {
+			t.Errorf("The quoteShellArg function returned %v but should have returned %v for: %v", output, test.expected, test.value)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package assistant
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+)
+
+// Main function of the inspect command
+func InspectRevision(revisionPath string, computePlatform string) {
+	fmt.Println("inspectRevision called on:", revisionPath)
+
+	revision, err := loadAppSpecRevision(revisionPath)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	bundleValid := validateRevisionBundle(revision)
+
+	appSpecBytes, err := revision.readAppSpec()
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if computePlatform == "" {
+		computePlatform = detectAppSpecComputePlatform(appSpecBytes)
+	}
+	if !isValidComputePlatform(computePlatform) {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.UndetectedAppSpecComputePlatformErr))
+	}
+
+	appSpecPath, _ := revision.getAppSpecPath()
+	fmt.Printf(errorHandling.InspectRevisionSummaryMsg, revisionPath, len(revision.getFiles()), appSpecPath, computePlatform)
+
+	// The files and scripts a server AppSpec references must be in the bundle
+	if computePlatform == "server" {
+		serverRevision = revision
+	}
+
+	if validationErr := runValidation(appSpecBytes, computePlatform); validationErr != nil {
+		errorHandling.HandleError(validationErr)
+	}
+
+	if !bundleValid {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.InvalidRevisionBundleErr))
+	}
+
+	fmt.Println(errorHandling.InspectRevisionPassedMsg)
+}
+
+// Validate the layout of the revision: one AppSpec, at the root, named the way CodeDeploy expects, and the symlinks in it
+func validateRevisionBundle(revision *appSpecRevision) bool {
+	bundleValid := true
+
+	if revision.rootDir != "" {
+		numOfErrors++
+		appSpecPath, _ := revision.getAppSpecPath()
+		fmt.Println(errorHandling.WrappedRevisionAppSpecErr, appSpecPath)
+		bundleValid = false
+	}
+
+	if appSpecPaths := revision.getAppSpecPaths(); len(appSpecPaths) > 1 {
+		fmt.Printf(errorHandling.MultipleRevisionAppSpecsWarn, appSpecPaths[0], appSpecPaths[1:])
+	}
+
+	var entryPaths []string
+	for entryPath := range revision.entries {
+		entryPaths = append(entryPaths, entryPath)
+	}
+	sort.Strings(entryPaths)
+
+	for _, entryPath := range entryPaths {
+		entry := revision.entries[entryPath]
+		name := path.Base(entryPath)
+
+		// appspec.yaml, AppSpec.yml, ...
+		if !entry.mode.IsDir() && !containsString(revisionAppSpecFileNames, name) && isRevisionAppSpecFileName(name) {
+			fmt.Println(errorHandling.MisnamedRevisionAppSpecWarn, entryPath)
+		}
+
+		if entry.mode&os.ModeSymlink == 0 {
+			continue
+		}
+
+		if isRevisionSymlinkOutside(entry) {
+			numOfErrors++
+			fmt.Printf(errorHandling.RevisionSymlinkOutsideRevisionErr, entryPath, entry.linkTarget)
+			bundleValid = false
+		} else {
+			fmt.Printf(errorHandling.RevisionSymlinkWarn, entryPath, entry.linkTarget)
+		}
+	}
+
+	return bundleValid
+}
+
+func isRevisionAppSpecFileName(name string) bool {
+	lowerName := strings.ToLower(name)
+
+	return lowerName == "appspec.yml" || lowerName == "appspec.yaml" || lowerName == "appspec.json"
+}
+
+// Absolute targets and targets that leave the revision with ../
+func isRevisionSymlinkOutside(entry revisionEntry) bool {
+	linkTarget := strings.Replace(entry.linkTarget, "\\", "/", -1)
+	if path.IsAbs(linkTarget) || windowsDrivePattern.MatchString(linkTarget) {
+		return true
+	}
+
+	target := path.Join(path.Dir(entry.path), linkTarget)
+
+	return target == ".." || strings.HasPrefix(target, "../")
+}
+
+// ECS and Lambda AppSpecs name the type of their resource, server AppSpecs have none
+func detectAppSpecComputePlatform(appSpecBytes []byte) string {
+	appSpecString := string(appSpecBytes)
+
+	switch {
+	case strings.Contains(appSpecString, "AWS::ECS::Service"):
+		return "ecs"
+	case strings.Contains(appSpecString, "AWS::Lambda::Function"):
+		return "lambda"
+	case strings.Contains(appSpecString, "files") || strings.Contains(appSpecString, "hooks"):
+		return "server"
+	}
+
+	return ""
+}
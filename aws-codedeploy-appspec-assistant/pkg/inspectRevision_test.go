@@ -0,0 +1,103 @@
+package assistant
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Test validateRevisionBundle
+func TestValidateRevisionBundle(t *testing.T) {
+	var tests = []struct {
+		name          string
+		revisionFiles map[string]string
+		expected      bool
+	}{
+		{"AppSpec at the root", testRevisionFiles, true},
+		{"Wrapping folder", map[string]string{"bundle/appspec.yml": "version: 0.0\n", "bundle/app/index.html": ""}, false},
+		{"More than one AppSpec", map[string]string{"appspec.yml": "version: 0.0\n", "lib/appspec.yml": "version: 0.0\n"}, true},
+		{"appspec.yaml next to appspec.yml", map[string]string{"appspec.yml": "version: 0.0\n", "appspec.yaml": "version: 0.0\n"}, true},
+	}
+
+	for _, test := range tests {
+		revisionDirPath := writeTestRevisionDir(t, test.revisionFiles)
+		revision, err := loadAppSpecRevision(revisionDirPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if valid := validateRevisionBundle(revision); valid != test.expected {
+			t.Errorf("The validateRevisionBundle function returned %v instead of %v for: %v", valid, test.expected, test.name)
+		}
+		os.RemoveAll(revisionDirPath)
+	}
+}
+
+// Test validateRevisionBundle with symlinks
+func TestValidateRevisionBundle_Symlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Creating symlinks needs extra privileges on Windows")
+	}
+
+	var tests = []struct {
+		name       string
+		linkTarget string
+		expected   bool
+	}{
+		{"Link inside the revision", "../app/index.html", true},
+		{"Absolute link", "/etc/passwd", false},
+		{"Link leaving the revision", "../../secrets", false},
+	}
+
+	for _, test := range tests {
+		revisionDirPath := writeTestRevisionDir(t, testRevisionFiles)
+		if err := os.Symlink(test.linkTarget, filepath.Join(revisionDirPath, "scripts", "link")); err != nil {
+			t.Fatal(err)
+		}
+
+		revision, err := loadAppSpecRevision(revisionDirPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if valid := validateRevisionBundle(revision); valid != test.expected {
+			t.Errorf("The validateRevisionBundle function returned %v instead of %v for: %v", valid, test.expected, test.name)
+		}
+		os.RemoveAll(revisionDirPath)
+	}
+}
+
+// Test the AppSpec of a revision without appspec.yml or appspec.json is not read
+func TestReadAppSpec_Misnamed(t *testing.T) {
+	revisionDirPath := writeTestRevisionDir(t, map[string]string{"appspec.yaml": "version: 0.0\n"})
+	defer os.RemoveAll(revisionDirPath)
+
+	revision, err := loadAppSpecRevision(revisionDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := revision.readAppSpec(); err == nil {
+		t.Errorf("The readAppSpec function read appspec.yaml")
+	}
+}
+
+// Test detectAppSpecComputePlatform
+func TestDetectAppSpecComputePlatform(t *testing.T) {
+	var tests = []struct {
+		appSpecString string
+		expected      string
+	}{
+		{ecsYamlString, "ecs"},
+		{lambdaYamlString, "lambda"},
+		{serverYamlString, "server"},
+		{"version: 0.0\n", ""},
+	}
+
+	for _, test := range tests {
+		if computePlatform := detectAppSpecComputePlatform([]byte(test.appSpecString)); computePlatform != test.expected {
+			t.Errorf("The detectAppSpecComputePlatform function returned %v instead of %v for: %v", computePlatform, test.expected, test.appSpecString)
+		}
+	}
+}
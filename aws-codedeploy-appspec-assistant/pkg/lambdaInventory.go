@@ -0,0 +1,151 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"encoding/json"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Set by the validate command
+// Saved output of list-versions-by-function and list-aliases to cross-check the Lambda AppSpec against
+var LambdaInventoryFilePaths []string
+
+// What the inventory files say about one function
+// versions is nil if there was no list-versions-by-function output for the function, aliases if there was no list-aliases output
+type lambdaFunctionInventory struct {
+	versions []string
+	aliases  map[string]models.LambdaFunctionAlias
+}
+
+// Functions by name the Lambda AppSpec is cross-checked against, nil if there is no inventory
+var lambdaInventory map[string]*lambdaFunctionInventory
+
+// Load the inventory files to cross-check the Lambda AppSpec against
+func setupLambdaInventory() error {
+	lambdaInventory = nil
+
+	if len(LambdaInventoryFilePaths) < 1 {
+		return nil
+	}
+
+	lambdaInventory = map[string]*lambdaFunctionInventory{}
+	for _, inventoryFilePath := range LambdaInventoryFilePaths {
+		inventoryBytes, err := ioutil.ReadFile(inventoryFilePath)
+		if err != nil {
+			return err
+		}
+
+		if err := addLambdaInventory(inventoryBytes); err != nil {
+			return fmt.Errorf(errorHandling.InvalidLambdaInventoryFileErr, inventoryFilePath, err)
+		}
+	}
+
+	return nil
+}
+
+// Add the versions or aliases of a list-versions-by-function or list-aliases output to the inventory
+func addLambdaInventory(inventoryBytes []byte) error {
+	var inventoryModel models.LambdaInventoryModel
+	if err := json.Unmarshal(inventoryBytes, &inventoryModel); err != nil {
+		return err
+	}
+
+	if inventoryModel.Versions == nil && inventoryModel.Aliases == nil {
+		return fmt.Errorf(errorHandling.EmptyLambdaInventoryErr)
+	}
+
+	for _, functionVersion := range inventoryModel.Versions {
+		functionName := functionVersion.FunctionName
+		if functionName == "" {
+			functionName = functionVersion.FunctionArn
+		}
+
+		functionInventory := getLambdaFunctionInventory(getLambdaFunctionName(functionName))
+		functionInventory.versions = append(functionInventory.versions, functionVersion.Version)
+	}
+
+	for _, functionAlias := range inventoryModel.Aliases {
+		functionInventory := getLambdaFunctionInventory(getLambdaFunctionName(functionAlias.AliasArn))
+		if functionInventory.aliases == nil {
+			functionInventory.aliases = map[string]models.LambdaFunctionAlias{}
+		}
+		functionInventory.aliases[functionAlias.Name] = functionAlias
+	}
+
+	return nil
+}
+
+func getLambdaFunctionInventory(functionName string) *lambdaFunctionInventory {
+	if _, ok := lambdaInventory[functionName]; !ok {
+		lambdaInventory[functionName] = &lambdaFunctionInventory{}
+	}
+
+	return lambdaInventory[functionName]
+}
+
+// Validate the function and alias exist, the alias points at CurrentVersion, and TargetVersion is published
+func validateLambdaInventory(lambdaProperties models.LambdaProperties, functionResourceName string) bool {
+	functionInventory, ok := lambdaInventory[getLambdaFunctionName(lambdaProperties.Name)]
+	if !ok {
+		numOfErrors++
+		fmt.Printf(errorHandling.MissingLambdaInventoryFunctionErr, getSortedLambdaInventoryKeys(lambdaInventory), functionResourceName)
+		return false
+	}
+
+	inventoryValid := true
+
+	if functionInventory.aliases != nil && lambdaProperties.Alias != "" {
+		if functionAlias, ok := functionInventory.aliases[lambdaProperties.Alias]; !ok {
+			inventoryValid = false
+			numOfErrors++
+			fmt.Printf(errorHandling.MissingLambdaInventoryAliasErr, getSortedLambdaAliasKeys(functionInventory.aliases), functionResourceName)
+		} else {
+			if functionAlias.FunctionVersion != lambdaProperties.CurrentVersion {
+				inventoryValid = false
+				numOfErrors++
+				fmt.Printf(errorHandling.MismatchedLambdaAliasVersionErr, lambdaProperties.Alias, functionAlias.FunctionVersion, lambdaProperties.CurrentVersion, functionResourceName)
+			}
+
+			if len(functionAlias.RoutingConfig.AdditionalVersionWeights) > 0 {
+				fmt.Printf(errorHandling.LambdaAliasRoutingConfigWarn, lambdaProperties.Alias, functionAlias.RoutingConfig.AdditionalVersionWeights, functionResourceName)
+			}
+		}
+	}
+
+	if functionInventory.versions != nil && lambdaProperties.TargetVersion != "" {
+		publishedVersions := getLambdaPublishedVersions(functionInventory.versions)
+		if !containsString(publishedVersions, lambdaProperties.TargetVersion) {
+			inventoryValid = false
+			numOfErrors++
+			fmt.Printf(errorHandling.UnpublishedLambdaTargetVersionErr, lambdaProperties.TargetVersion, publishedVersions, functionResourceName)
+		}
+	}
+
+	return inventoryValid
+}
+
+// $LATEST is not a published version
+func getLambdaPublishedVersions(versions []string) []string {
+	var publishedVersions []string
+	for _, version := range versions {
+		if version != "$LATEST" {
+			publishedVersions = append(publishedVersions, version)
+		}
+	}
+
+	return publishedVersions
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
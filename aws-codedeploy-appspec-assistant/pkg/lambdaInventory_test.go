@@ -0,0 +1,95 @@
+package assistant
+
+import (
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+var testLambdaListVersionsJsonString = `{
+  "Versions": [
+    {"FunctionName": "myLambdaFunction", "FunctionArn": "arn:aws:lambda:us-east-1:111122223333:function:myLambdaFunction:$LATEST", "Version": "$LATEST"},
+    {"FunctionName": "myLambdaFunction", "FunctionArn": "arn:aws:lambda:us-east-1:111122223333:function:myLambdaFunction:1", "Version": "1"},
+    {"FunctionName": "myLambdaFunction", "FunctionArn": "arn:aws:lambda:us-east-1:111122223333:function:myLambdaFunction:2", "Version": "2"}
+  ]
+}`
+
+var testLambdaListAliasesJsonString = `{
+  "Aliases": [
+    {"AliasArn": "arn:aws:lambda:us-east-1:111122223333:function:myLambdaFunction:live", "Name": "live", "FunctionVersion": "1"},
+    {"AliasArn": "arn:aws:lambda:us-east-1:111122223333:function:myLambdaFunction:shifting", "Name": "shifting", "FunctionVersion": "1",
+     "RoutingConfig": {"AdditionalVersionWeights": {"2": 0.1}}}
+  ]
+}`
+
+func setTestLambdaInventory(t *testing.T) {
+	lambdaInventory = map[string]*lambdaFunctionInventory{}
+	for _, inventoryString := range []string{testLambdaListVersionsJsonString, testLambdaListAliasesJsonString} {
+		if err := addLambdaInventory([]byte(inventoryString)); err != nil {
+			t.Fatalf("addLambdaInventory FAILED: %v", err)
+		}
+	}
+}
+
+// Test addLambdaInventory
+func TestAddLambdaInventory_InvalidInput(t *testing.T) {
+	lambdaInventory = map[string]*lambdaFunctionInventory{}
+	defer func() { lambdaInventory = nil }()
+
+	for _, inventoryString := range []string{`{"Functions": []}`, `not json`} {
+		if err := addLambdaInventory([]byte(inventoryString)); err == nil {
+			t.Errorf("The addLambdaInventory function succeeded but should have failed for: %v", inventoryString)
+		}
+	}
+}
+
+// Test validateLambdaInventory
+func TestValidateLambdaInventory(t *testing.T) {
+	setTestLambdaInventory(t)
+	defer func() { lambdaInventory = nil }()
+
+	var tests = []struct {
+		name             string
+		lambdaProperties models.LambdaProperties
+		expectedOutput   bool
+	}{
+		{"Alias points at CurrentVersion",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "2"}, true},
+		{"Function ARN",
+			models.LambdaProperties{Name: "arn:aws:lambda:us-east-1:111122223333:function:myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "2"}, true},
+		{"Alias still shifting traffic",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "shifting", CurrentVersion: "1", TargetVersion: "2"}, true},
+		{"Unknown function",
+			models.LambdaProperties{Name: "otherFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Unknown alias",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "prod", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Alias already shifted by a previous deployment",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "2", TargetVersion: "1"}, false},
+		{"TargetVersion not published",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "3"}, false},
+		{"TargetVersion $LATEST",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "$LATEST"}, false},
+	}
+
+	for _, test := range tests {
+		if output := validateLambdaInventory(test.lambdaProperties, "myLambdaFunction"); output != test.expectedOutput {
+			t.Errorf("The validateLambdaInventory function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test validateLambdaInventory with only one of the two outputs
+func TestValidateLambdaInventory_PartialInventory(t *testing.T) {
+	lambdaInventory = map[string]*lambdaFunctionInventory{}
+	defer func() { lambdaInventory = nil }()
+
+	if err := addLambdaInventory([]byte(testLambdaListAliasesJsonString)); err != nil {
+		t.Fatalf("addLambdaInventory FAILED: %v", err)
+	}
+
+	// Without list-versions-by-function output the TargetVersion cannot be checked
+	lambdaProperties := models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "3"}
+	if !validateLambdaInventory(lambdaProperties, "myLambdaFunction") {
+		t.Errorf("The validateLambdaInventory function checked the TargetVersion without list-versions-by-function output")
+	}
+}
@@ -0,0 +1,275 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+)
+
+// A lifecycle event of the deployment and what its hooks point at
+type lifecycleGraphEvent struct {
+	name       string
+	isReserved bool     // Run by CodeDeploy, cannot have hooks
+	isOptional bool     // Only runs in deployments with a load balancer
+	hooks      []string // Functions (ECS and Lambda) or scripts (EC2/On-Prem) of the event
+}
+
+// A resource of the AppSpec, next to the lifecycle event that deploys it
+type lifecycleGraphResource struct {
+	label          string
+	lifecycleEvent string
+}
+
+type lifecycleGraph struct {
+	computePlatform string
+	events          []lifecycleGraphEvent
+	resources       []lifecycleGraphResource
+}
+
+// Colors of the graph, the same for both formats
+const lifecycleGraphHookedColor = "#d1e7dd"
+const lifecycleGraphReservedColor = "#e9ecef"
+
+// Main function of the graph command
+func GraphAppSpec(filePath string, computePlatform string, format string, outFilePath string) {
+	fmt.Println("graphAppSpec called on:", filePath)
+
+	if !containsString(globalVars.AppSpecGraphFormats[:], format) {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.UnsupportedGraphFormatErr, globalVars.AppSpecGraphFormats))
+	}
+
+	if computePlatform == "" {
+		appSpecBytes, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			errorHandling.HandleError(err)
+		}
+		if computePlatform = detectAppSpecComputePlatform(appSpecBytes); computePlatform == "" {
+			errorHandling.HandleError(fmt.Errorf(errorHandling.UndetectedAppSpecComputePlatformErr))
+		}
+	}
+
+	appSpecBytes, err := loadAppSpecFile(filePath, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	graph, err := getLifecycleGraph(appSpecBytes, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	graphText := renderMermaidGraph(graph)
+	if format == "dot" {
+		graphText = renderDotGraph(graph)
+	}
+
+	if outFilePath == "" {
+		fmt.Println()
+		fmt.Print(graphText)
+		return
+	}
+
+	if err := ioutil.WriteFile(outFilePath, []byte(graphText), 0644); err != nil {
+		errorHandling.HandleError(err)
+	}
+	fmt.Printf(errorHandling.WroteLifecycleGraphMsg, format, outFilePath)
+}
+
+// The lifecycle events of the compute platform in order, with the hooks and resources of the AppSpec
+// Server graphs show every event of an in-place deployment, the ones that need a load balancer are optional
+func getLifecycleGraph(appSpecBytes []byte, computePlatform string) (lifecycleGraph, error) {
+	graph := lifecycleGraph{computePlatform: computePlatform}
+	hooks := map[string][]string{}
+	var lifecycleEvents, supportedHooks []string
+
+	switch computePlatform {
+	case "ecs":
+		ecsAppSpecModel, err := getEcsAppSpecObjFromString(appSpecBytes)
+		if err != nil {
+			return graph, err
+		}
+		for _, hook := range getAppSpecHookList(ecsAppSpecModel.Hooks) {
+			hooks[hook.name] = append(hooks[hook.name], hook.function)
+		}
+		for _, resource := range ecsAppSpecModel.Resources {
+			label := "TargetService: " + resource.TargetService.Properties.TaskDefinition
+			if loadBalancerInfo := resource.TargetService.Properties.LoadBalancerInfo; loadBalancerInfo.ContainerName != "" {
+				label += fmt.Sprintf(" (%v:%d)", loadBalancerInfo.ContainerName, loadBalancerInfo.ContainerPort)
+			}
+			graph.resources = append(graph.resources, lifecycleGraphResource{label, "Install"})
+		}
+		lifecycleEvents, supportedHooks = globalVars.AppSpecEcsLifecycleEvents[:], globalVars.AppSpecSupportedEcsHooks[:]
+
+	case "lambda":
+		lambdaAppSpecModel, err := getLambdaAppSpecObjFromString(appSpecBytes)
+		if err != nil {
+			return graph, err
+		}
+		for _, hook := range getAppSpecHookList(lambdaAppSpecModel.Hooks) {
+			hooks[hook.name] = append(hooks[hook.name], hook.function)
+		}
+		for _, resource := range lambdaAppSpecModel.Resources {
+			for _, name := range getSortedFunctionKeys(resource) {
+				properties := resource[name].Properties
+				label := fmt.Sprintf("%v: %v:%v %v -> %v", name, properties.Name, properties.Alias, properties.CurrentVersion, properties.TargetVersion)
+				graph.resources = append(graph.resources, lifecycleGraphResource{label, "AllowTraffic"})
+			}
+		}
+		lifecycleEvents, supportedHooks = globalVars.AppSpecLambdaLifecycleEvents[:], globalVars.AppSpecSupportedLambdaHooks[:]
+
+	case "server":
+		serverAppSpecModel, err := getServerAppSpecObjFromString(appSpecBytes)
+		if err != nil {
+			return graph, err
+		}
+		for hook, hookScripts := range serverAppSpecModel.Hooks {
+			for _, hookScript := range hookScripts {
+				hooks[hook] = append(hooks[hook], hookScript.Location)
+			}
+		}
+		for _, file := range serverAppSpecModel.Files {
+			graph.resources = append(graph.resources, lifecycleGraphResource{file.Source + " -> " + file.Destination, "Install"})
+		}
+		lifecycleEvents = append(append([]string{"Start"}, globalVars.AppSpecServerLifecycleEvents[:]...), "End")
+		for _, lifecycleEvent := range lifecycleEvents {
+			if !containsString(globalVars.AppSpecServerReservedLifecycleEvents[:], lifecycleEvent) {
+				supportedHooks = append(supportedHooks, lifecycleEvent)
+			}
+		}
+
+	default:
+		return graph, fmt.Errorf(errorHandling.ComputePlatformErr)
+	}
+
+	for _, lifecycleEvent := range lifecycleEvents {
+		graph.events = append(graph.events, lifecycleGraphEvent{
+			name:       lifecycleEvent,
+			isReserved: !containsString(supportedHooks, lifecycleEvent),
+			isOptional: computePlatform == "server" && isServerTrafficLifecycleEvent(lifecycleEvent),
+			hooks:      hooks[lifecycleEvent],
+		})
+	}
+
+	for _, hook := range getSortedStringListKeys(hooks) {
+		if !containsString(supportedHooks, hook) {
+			fmt.Printf(errorHandling.NotInLifecycleGraphHookWarn, hook, computePlatform)
+		}
+	}
+
+	return graph, nil
+}
+
+// Mermaid flowchart: reserved events are rounded, hooks point at their functions or scripts,
+// events with hooks are highlighted and the optional ones are dashed
+func renderMermaidGraph(graph lifecycleGraph) string {
+	var builder strings.Builder
+	var hookedIds, reservedIds, optionalIds []string
+
+	fmt.Fprintf(&builder, "flowchart TD\n    %%%% CodeDeploy %v deployment lifecycle\n", graph.computePlatform)
+
+	for i, event := range graph.events {
+		id := fmt.Sprintf("e%d", i)
+		if event.isReserved {
+			fmt.Fprintf(&builder, "    %v([\"%v\"])\n", id, escapeMermaidLabel(event.name))
+			reservedIds = append(reservedIds, id)
+		} else {
+			fmt.Fprintf(&builder, "    %v[\"%v\"]\n", id, escapeMermaidLabel(event.name))
+		}
+		if len(event.hooks) > 0 {
+			hookedIds = append(hookedIds, id)
+		}
+		if event.isOptional {
+			optionalIds = append(optionalIds, id)
+		}
+		if i > 0 {
+			fmt.Fprintf(&builder, "    e%d --> %v\n", i-1, id)
+		}
+
+		for j, hook := range event.hooks {
+			fmt.Fprintf(&builder, "    %v -.-> %vh%d[/\"%v\"/]\n", id, id, j, escapeMermaidLabel(hook))
+		}
+	}
+
+	for i, resource := range graph.resources {
+		if eventId, ok := getLifecycleGraphEventId(graph, resource.lifecycleEvent); ok {
+			fmt.Fprintf(&builder, "    r%d[(\"%v\")] --- %v\n", i, escapeMermaidLabel(resource.label), eventId)
+		}
+	}
+
+	fmt.Fprintf(&builder, "    classDef hooked fill:%v,stroke-width:2px\n", lifecycleGraphHookedColor)
+	fmt.Fprintf(&builder, "    classDef reserved fill:%v\n", lifecycleGraphReservedColor)
+	fmt.Fprintf(&builder, "    classDef optional stroke-dasharray:5 5\n")
+	for _, class := range []struct {
+		name string
+		ids  []string
+	}{{"hooked", hookedIds}, {"reserved", reservedIds}, {"optional", optionalIds}} {
+		if len(class.ids) > 0 {
+			fmt.Fprintf(&builder, "    class %v %v\n", strings.Join(class.ids, ","), class.name)
+		}
+	}
+
+	return builder.String()
+}
+
+// Graphviz DOT digraph with the same shapes and highlighting as the Mermaid flowchart
+func renderDotGraph(graph lifecycleGraph) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "// CodeDeploy %v deployment lifecycle\n", graph.computePlatform)
+	builder.WriteString("digraph appspec {\n    rankdir=TB;\n    node [shape=box];\n")
+
+	for i, event := range graph.events {
+		id := fmt.Sprintf("e%d", i)
+		shape, styles, fillColor := "box", []string{"filled"}, "white"
+		if event.isReserved {
+			shape, fillColor = "oval", lifecycleGraphReservedColor
+		}
+		if len(event.hooks) > 0 {
+			styles, fillColor = append(styles, "bold"), lifecycleGraphHookedColor
+		}
+		if event.isOptional {
+			styles = append(styles, "dashed")
+		}
+		fmt.Fprintf(&builder, "    %v [label=\"%v\", shape=%v, style=\"%v\", fillcolor=\"%v\"];\n", id, escapeDotLabel(event.name), shape, strings.Join(styles, ","), fillColor)
+		if i > 0 {
+			fmt.Fprintf(&builder, "    e%d -> %v;\n", i-1, id)
+		}
+
+		for j, hook := range event.hooks {
+			fmt.Fprintf(&builder, "    %vh%d [label=\"%v\", shape=parallelogram];\n", id, j, escapeDotLabel(hook))
+			fmt.Fprintf(&builder, "    %v -> %vh%d [style=dashed];\n", id, id, j)
+		}
+	}
+
+	for i, resource := range graph.resources {
+		if eventId, ok := getLifecycleGraphEventId(graph, resource.lifecycleEvent); ok {
+			fmt.Fprintf(&builder, "    r%d [label=\"%v\", shape=cylinder];\n", i, escapeDotLabel(resource.label))
+			fmt.Fprintf(&builder, "    r%d -> %v [style=dotted, arrowhead=none];\n", i, eventId)
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func getLifecycleGraphEventId(graph lifecycleGraph, lifecycleEvent string) (string, bool) {
+	for i, event := range graph.events {
+		if event.name == lifecycleEvent {
+			return fmt.Sprintf("e%d", i), true
+		}
+	}
+
+	return "", false
+}
+
+func escapeMermaidLabel(label string) string {
+	return strings.Replace(label, "\"", "#quot;", -1)
+}
+
+func escapeDotLabel(label string) string {
+	return strings.Replace(strings.Replace(label, "\\", "\\\\", -1), "\"", "\\\"", -1)
+}
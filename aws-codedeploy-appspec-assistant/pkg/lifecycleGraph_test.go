@@ -0,0 +1,174 @@
+package assistant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testGraphLambdaYamlString = `version: 0.0
+Resources:
+  - myFunction:
+      Type: AWS::Lambda::Function
+      Properties:
+        Name: "my-function"
+        Alias: "live"
+        CurrentVersion: "1"
+        TargetVersion: "2"
+Hooks:
+  - BeforeAllowTraffic: "CodeDeployHook_Before"`
+
+var testGraphServerYamlString = `version: 0.0
+os: linux
+files:
+  - source: /
+    destination: /opt/app
+hooks:
+  BeforeInstall:
+    - location: scripts/deps.sh
+    - location: scripts/clean.sh
+  BeforeBlockTraffic:
+    - location: scripts/drain.sh`
+
+// Test getLifecycleGraph
+func TestGetLifecycleGraph(t *testing.T) {
+	fileExtension = "yml"
+
+	var tests = []struct {
+		name            string
+		appSpec         string
+		computePlatform string
+		events          []string
+		reservedEvents  []string
+		optionalEvents  []string
+		hooks           map[string][]string
+		resources       []lifecycleGraphResource
+	}{
+		{"ECS",
+			testHookEventsEcsYamlString, "ecs",
+			[]string{"Start", "BeforeInstall", "Install", "AfterInstall", "AllowTestTraffic", "AfterAllowTestTraffic", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic", "End"},
+			[]string{"Start", "Install", "AllowTestTraffic", "AllowTraffic", "End"},
+			nil,
+			map[string][]string{
+				"BeforeInstall":         {"arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeInstall:live"},
+				"AfterAllowTestTraffic": {"CodeDeployHook_AfterAllowTestTraffic"},
+				"AfterAllowTraffic":     {"CodeDeployHook_AfterAllowTraffic"},
+			},
+			[]lifecycleGraphResource{{"TargetService: web:3 (web:8080)", "Install"}},
+		},
+		{"Lambda",
+			testGraphLambdaYamlString, "lambda",
+			[]string{"Start", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic", "End"},
+			[]string{"Start", "AllowTraffic", "End"},
+			nil,
+			map[string][]string{"BeforeAllowTraffic": {"CodeDeployHook_Before"}},
+			[]lifecycleGraphResource{{"myFunction: my-function:live 1 -> 2", "AllowTraffic"}},
+		},
+		{"Server",
+			testGraphServerYamlString, "server",
+			[]string{"Start", "BeforeBlockTraffic", "BlockTraffic", "AfterBlockTraffic", "ApplicationStop", "DownloadBundle", "BeforeInstall", "Install",
+				"AfterInstall", "ApplicationStart", "ValidateService", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic", "End"},
+			[]string{"Start", "BlockTraffic", "DownloadBundle", "Install", "AllowTraffic", "End"},
+			[]string{"BeforeBlockTraffic", "BlockTraffic", "AfterBlockTraffic", "BeforeAllowTraffic", "AllowTraffic", "AfterAllowTraffic"},
+			map[string][]string{
+				"BeforeInstall":      {"scripts/deps.sh", "scripts/clean.sh"},
+				"BeforeBlockTraffic": {"scripts/drain.sh"},
+			},
+			[]lifecycleGraphResource{{"/ -> /opt/app", "Install"}},
+		},
+	}
+
+	for _, test := range tests {
+		graph, err := getLifecycleGraph([]byte(test.appSpec), test.computePlatform)
+		if err != nil {
+			t.Errorf("The getLifecycleGraph function failed with %v but should have succeeded for: %v", err, test.name)
+			continue
+		}
+
+		var events, reservedEvents, optionalEvents []string
+		hooks := map[string][]string{}
+		for _, event := range graph.events {
+			events = append(events, event.name)
+			if event.isReserved {
+				reservedEvents = append(reservedEvents, event.name)
+			}
+			if event.isOptional {
+				optionalEvents = append(optionalEvents, event.name)
+			}
+			if len(event.hooks) > 0 {
+				hooks[event.name] = event.hooks
+			}
+		}
+
+		if !reflect.DeepEqual(events, test.events) {
+			t.Errorf("The getLifecycleGraph function returned the events %v but should have returned %v for: %v", events, test.events, test.name)
+		}
+		if !reflect.DeepEqual(reservedEvents, test.reservedEvents) {
+			t.Errorf("The getLifecycleGraph function returned the reserved events %v but should have returned %v for: %v", reservedEvents, test.reservedEvents, test.name)
+		}
+		if !reflect.DeepEqual(optionalEvents, test.optionalEvents) {
+			t.Errorf("The getLifecycleGraph function returned the optional events %v but should have returned %v for: %v", optionalEvents, test.optionalEvents, test.name)
+		}
+		if !reflect.DeepEqual(hooks, test.hooks) {
+			t.Errorf("The getLifecycleGraph function returned the hooks %v but should have returned %v for: %v", hooks, test.hooks, test.name)
+		}
+		if !reflect.DeepEqual(graph.resources, test.resources) {
+			t.Errorf("The getLifecycleGraph function returned the resources %v but should have returned %v for: %v", graph.resources, test.resources, test.name)
+		}
+	}
+}
+
+var testLifecycleGraph = lifecycleGraph{
+	computePlatform: "server",
+	events: []lifecycleGraphEvent{
+		{name: "Start", isReserved: true},
+		{name: "BeforeInstall", hooks: []string{`scripts\"deps".ps1`}},
+		{name: "AllowTraffic", isReserved: true, isOptional: true},
+	},
+	resources: []lifecycleGraphResource{{"/ -> C:\\app", "BeforeInstall"}},
+}
+
+// Test renderMermaidGraph
+func TestRenderMermaidGraph(t *testing.T) {
+	output := renderMermaidGraph(testLifecycleGraph)
+
+	for _, expected := range []string{
+		"flowchart TD\n",
+		`    e0(["Start"])`,
+		`    e1["BeforeInstall"]`,
+		"    e0 --> e1\n",
+		`    e1 -.-> e1h0[/"scripts\#quot;deps#quot;.ps1"/]`,
+		`    r0[("/ -> C:\app")] --- e1`,
+		"    class e1 hooked\n",
+		"    class e0,e2 reserved\n",
+		"    class e2 optional\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("The renderMermaidGraph function output does not contain %q:\n%v", expected, output)
+		}
+	}
+}
+
+// Test renderDotGraph
+func TestRenderDotGraph(t *testing.T) {
+	output := renderDotGraph(testLifecycleGraph)
+
+	for _, expected := range []string{
+		"digraph appspec {\n",
+		`    e0 [label="Start", shape=oval, style="filled", fillcolor="#e9ecef"];`,
+		`    e1 [label="BeforeInstall", shape=box, style="filled,bold", fillcolor="#d1e7dd"];`,
+		`    e2 [label="AllowTraffic", shape=oval, style="filled,dashed", fillcolor="#e9ecef"];`,
+		"    e1 -> e2;\n",
+		`    e1h0 [label="scripts\\\"deps\".ps1", shape=parallelogram];`,
+		`    r0 [label="/ -> C:\\app", shape=cylinder];`,
+		"    r0 -> e1 [style=dotted, arrowhead=none];\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("The renderDotGraph function output does not contain %q:\n%v", expected, output)
+		}
+	}
+
+	if !strings.HasSuffix(output, "}\n") {
+		t.Errorf("The renderDotGraph function output does not end the digraph:\n%v", output)
+	}
+}
@@ -0,0 +1,173 @@
+package assistant
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// A problem found in a hook script, errors fail the deployment and warnings can make it hang or hide failures
+type hookScriptFinding struct {
+	isError bool
+	message string
+}
+
+// Script types only one OS can run
+var linuxHookScriptExtensions = []string{".sh", ".bash"}
+var windowsHookScriptExtensions = []string{".ps1", ".bat", ".cmd", ".exe"}
+
+// Commands that wait for input
+// read from a pipe or file does not wait, and sudo -n fails instead of prompting for a password
+var shellReadPattern = regexp.MustCompile(`^\s*read(\s|$)`)
+var shellSudoPattern = regexp.MustCompile(`(^|[;&|(]|\s)sudo(\s|$)`)
+var shellNonInteractiveSudoPattern = regexp.MustCompile(`sudo(\s+(-[A-Za-z]+(\s+[^-\s]\S*)?|--[a-z-]+(=\S+)?))*?\s+(-[A-Za-z]*n[A-Za-z]*|--non-interactive)(\s|$)`)
+var powerShellPromptPattern = regexp.MustCompile(`(?i)(^|[\s;(|])(Read-Host|Get-Credential|pause)(\s|$|\))`)
+
+// Ways for a script to exit non-zero other than its last command failing
+var shellErrexitPattern = regexp.MustCompile(`(?m)(^|\s)set\s+(-[A-Za-z]*e|-o\s+errexit)|^#!\S+\s+-[A-Za-z]*e`)
+var shellFailingExitPattern = regexp.MustCompile(`(?m)(^|[;&|\s])(exit|return)\s+([1-9]|\$)`)
+var powerShellFailurePattern = regexp.MustCompile(`(?im)\$ErrorActionPreference\s*=\s*['"]Stop['"]|(^|[;\s])(exit\s+([1-9]|\$)|throw(\s|$))`)
+var successfulExitPattern = regexp.MustCompile(`(?i)^(exit(\s+0)?|true|:)$`)
+
+// Lint every hook script of the revision once, even if more than one hook runs it
+func lintServerHookScripts(serverHooks map[string][]models.Hook, appSpecOS string) bool {
+	scriptsValid := true
+	lintedLocations := map[string]bool{}
+
+	for _, hook := range getSortedHookKeys(serverHooks) {
+		for _, hookScript := range serverHooks[hook] {
+			location := cleanRevisionPath(hookScript.Location)
+			if hookScript.Location == "" || lintedLocations[location] {
+				continue
+			}
+			lintedLocations[location] = true
+
+			entry, ok := serverRevision.getEntry(location)
+			if !ok || !entry.mode.IsRegular() {
+				continue
+			}
+
+			content, err := serverRevision.readFile(entry.path)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			for _, finding := range getHookScriptFindings(hookScript.Location, content, entry.mode, appSpecOS) {
+				if finding.isError {
+					numOfErrors++
+					scriptsValid = false
+				}
+				fmt.Print(finding.message)
+			}
+		}
+	}
+
+	return scriptsValid
+}
+
+// Lint the content of one hook script for the OS of the AppSpec
+func getHookScriptFindings(location string, content []byte, mode os.FileMode, appSpecOS string) []hookScriptFinding {
+	var findings []hookScriptFinding
+	extension := strings.ToLower(path.Ext(location))
+
+	// Binaries are not linted
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil
+	}
+
+	if appSpecOS == "linux" && containsString(windowsHookScriptExtensions, extension) {
+		return []hookScriptFinding{{true, fmt.Sprintf(errorHandling.UnsupportedHookScriptTypeErr, extension, appSpecOS, location)}}
+	}
+	if appSpecOS == "windows" && containsString(linuxHookScriptExtensions, extension) {
+		return []hookScriptFinding{{true, fmt.Sprintf(errorHandling.UnsupportedHookScriptTypeErr, extension, appSpecOS, location)}}
+	}
+
+	if appSpecOS == "windows" {
+		if extension == ".ps1" {
+			findings = append(findings, getScriptInteractiveFindings(location, content, true)...)
+			if !powerShellFailurePattern.Match(content) && isScriptEndingWithSuccess(content) {
+				findings = append(findings, hookScriptFinding{false, fmt.Sprintf(errorHandling.PowerShellHookScriptNeverFailsWarn, location)})
+			}
+		}
+		return findings
+	}
+
+	if !bytes.HasPrefix(content, []byte("#!")) {
+		findings = append(findings, hookScriptFinding{true, fmt.Sprintf(errorHandling.MissingHookScriptShebangErr, location)})
+	}
+
+	if bytes.Contains(content, []byte("\r\n")) {
+		findings = append(findings, hookScriptFinding{true, fmt.Sprintf(errorHandling.CrlfHookScriptErr, location)})
+	}
+
+	if mode&0111 == 0 {
+		findings = append(findings, hookScriptFinding{false, fmt.Sprintf(errorHandling.NotExecutableHookScriptWarn, location)})
+	}
+
+	findings = append(findings, getScriptInteractiveFindings(location, content, false)...)
+
+	if !shellErrexitPattern.Match(content) && !shellFailingExitPattern.Match(content) && isScriptEndingWithSuccess(content) {
+		findings = append(findings, hookScriptFinding{false, fmt.Sprintf(errorHandling.HookScriptNeverFailsWarn, location)})
+	}
+
+	return findings
+}
+
+// Lines that prompt for input, comments are skipped
+func getScriptInteractiveFindings(location string, content []byte, isPowerShell bool) []hookScriptFinding {
+	var findings []hookScriptFinding
+
+	for i, line := range getScriptLines(content) {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		command := ""
+		if isPowerShell {
+			if match := powerShellPromptPattern.FindStringSubmatch(line); match != nil {
+				command = match[2]
+			}
+		} else if shellReadPattern.MatchString(line) && !strings.Contains(line, "<") {
+			command = "read"
+		} else if shellSudoPattern.MatchString(line) && !shellNonInteractiveSudoPattern.MatchString(line) {
+			command = "sudo without -n"
+		}
+
+		if command != "" {
+			findings = append(findings, hookScriptFinding{false, fmt.Sprintf(errorHandling.InteractiveHookScriptWarn, i+1, command, location)})
+		}
+	}
+
+	return findings
+}
+
+// The last command of the script is exit 0 (or true), so whatever failed before it does not count
+func isScriptEndingWithSuccess(content []byte) bool {
+	lines := getScriptLines(content)
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i] == "" || strings.HasPrefix(lines[i], "#") {
+			continue
+		}
+		return successfulExitPattern.MatchString(lines[i])
+	}
+
+	return false
+}
+
+// Trimmed lines, with their line numbers kept by index
+func getScriptLines(content []byte) []string {
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return lines
+}
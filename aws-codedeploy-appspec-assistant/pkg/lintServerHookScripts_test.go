@@ -0,0 +1,84 @@
+package assistant
+
+import (
+	"os"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Test getHookScriptFindings
+func TestGetHookScriptFindings(t *testing.T) {
+	var tests = []struct {
+		name             string
+		location         string
+		content          string
+		mode             os.FileMode
+		appSpecOS        string
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{"Clean shell script", "scripts/start.sh", "#!/bin/bash\nset -e\nsystemctl start app\n", 0755, "linux", 0, 0},
+		{"Missing shebang", "scripts/start.sh", "systemctl start app\n", 0755, "linux", 1, 0},
+		{"CRLF line endings", "scripts/start.sh", "#!/bin/bash\r\nsystemctl start app\r\n", 0755, "linux", 1, 0},
+		{"Not executable", "scripts/start.sh", "#!/bin/bash\nsystemctl start app\n", 0644, "linux", 0, 1},
+		{"PowerShell script on Linux", "scripts/start.ps1", "Start-Service app\n", 0755, "linux", 1, 0},
+		{"Batch script on Linux", "scripts/start.bat", "net start app\n", 0755, "linux", 1, 0},
+		{"Shell script on Windows", "scripts/start.sh", "#!/bin/bash\n", 0644, "windows", 1, 0},
+		{"Interactive read", "scripts/start.sh", "#!/bin/bash\nread -p 'Continue?' answer\n", 0755, "linux", 0, 1},
+		{"read from a file", "scripts/start.sh", "#!/bin/bash\nwhile read line; do echo $line; done < hosts\n", 0755, "linux", 0, 0},
+		{"sudo prompting for a password", "scripts/start.sh", "#!/bin/bash\nsudo systemctl start app\n", 0755, "linux", 0, 1},
+		{"Non-interactive sudo", "scripts/start.sh", "#!/bin/bash\nsudo -n systemctl start app\nsudo -u app --non-interactive true\n", 0755, "linux", 0, 0},
+		{"Commented out sudo", "scripts/start.sh", "#!/bin/bash\n# sudo systemctl start app\n", 0755, "linux", 0, 0},
+		{"Always exits 0", "scripts/start.sh", "#!/bin/bash\nsystemctl start app\nexit 0\n", 0755, "linux", 0, 1},
+		{"Exits non-zero on errors", "scripts/start.sh", "#!/bin/bash\nsystemctl start app || exit 1\nexit 0\n", 0755, "linux", 0, 0},
+		{"errexit in the shebang", "scripts/start.sh", "#!/bin/bash -e\nsystemctl start app\ntrue\n", 0755, "linux", 0, 0},
+		{"PowerShell prompt", "scripts/start.ps1", "$name = Read-Host 'Name'\n", 0644, "windows", 0, 1},
+		{"PowerShell always exits 0", "scripts/start.ps1", "Start-Service app\nExit 0\n", 0644, "windows", 0, 1},
+		{"PowerShell stops on errors", "scripts/start.ps1", "$ErrorActionPreference = 'Stop'\nStart-Service app\nexit 0\n", 0644, "windows", 0, 0},
+		{"Binary", "bin/start", "\x7fELF\x00\x01", 0755, "linux", 0, 0},
+	}
+
+	for _, test := range tests {
+		numOfFindingErrors, numOfFindingWarnings := 0, 0
+		for _, finding := range getHookScriptFindings(test.location, []byte(test.content), test.mode, test.appSpecOS) {
+			if finding.isError {
+				numOfFindingErrors++
+			} else {
+				numOfFindingWarnings++
+			}
+		}
+
+		if numOfFindingErrors != test.expectedErrors || numOfFindingWarnings != test.expectedWarnings {
+			t.Errorf("The getHookScriptFindings function found %d errors and %d warnings instead of %d and %d for: %v",
+				numOfFindingErrors, numOfFindingWarnings, test.expectedErrors, test.expectedWarnings, test.name)
+		}
+	}
+}
+
+// Test lintServerHookScripts lints the scripts of the revision
+func TestLintServerHookScripts(t *testing.T) {
+	revisionDirPath := writeTestRevisionDir(t, map[string]string{
+		"appspec.yml":      "version: 0.0\n",
+		"scripts/start.sh": "#!/bin/bash\nsystemctl start app\n",
+		"scripts/stop.sh":  "systemctl stop app\n",
+	})
+	defer os.RemoveAll(revisionDirPath)
+
+	revision, err := loadAppSpecRevision(revisionDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverRevision = revision
+	defer func() { serverRevision = nil }()
+
+	validHooks := map[string][]models.Hook{"ApplicationStart": {{Location: "scripts/start.sh"}}, "ValidateService": {{Location: "scripts/start.sh"}}}
+	if !lintServerHookScripts(validHooks, "linux") {
+		t.Errorf("The lintServerHookScripts function failed for a valid script")
+	}
+
+	invalidHooks := map[string][]models.Hook{"ApplicationStop": {{Location: "scripts/stop.sh"}}}
+	if lintServerHookScripts(invalidHooks, "linux") {
+		t.Errorf("The lintServerHookScripts function succeeded for a script without a shebang")
+	}
+}
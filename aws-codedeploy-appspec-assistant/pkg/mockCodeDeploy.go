@@ -0,0 +1,335 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"encoding/json"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// X-Amz-Target values of the CodeDeploy API actions that are served
+const (
+	putLifecycleEventHookExecutionStatusTarget = "CodeDeploy_20141006.PutLifecycleEventHookExecutionStatus"
+	getDeploymentTarget                        = "CodeDeploy_20141006.GetDeployment"
+)
+
+// Statuses of the hooks of the mock deployment
+// Hook functions report Succeeded or Failed, the others are set by the mock
+const (
+	hookStatusPending    = "Pending"
+	hookStatusSucceeded  = "Succeeded"
+	hookStatusFailed     = "Failed"
+	hookStatusSkipped    = "Skipped"
+	hookStatusNoResponse = "NotReported"
+)
+
+// Names the mock deployment reports in GetDeployment
+const (
+	mockCodeDeployApplicationName     = "appSpecAssistant-mock-application"
+	mockCodeDeployDeploymentGroupName = "appSpecAssistant-mock-deployment-group"
+)
+
+// Environment variable recent AWS SDKs read the CodeDeploy endpoint from
+const mockCodeDeployEndpointEnvVar = "AWS_ENDPOINT_URL_CODEDEPLOY"
+
+// sam local invoke --env-vars file written next to the events, the functions run in Docker and do not get the environment of the invoke command
+const mockCodeDeployEnvVarsFileName = "env-vars.json"
+
+// Local stand-in for the CodeDeploy API a hook function calls
+// Records the status each hook reports
+type mockCodeDeploy struct {
+	mutex           sync.Mutex
+	deploymentId    string
+	computePlatform string
+	status          string
+	hookStatuses    map[string]string
+}
+
+type mockCodeDeployError struct {
+	statusCode int
+	errorType  string
+	message    string
+}
+
+// Main function of the mock-codedeploy command
+// host is the address to listen on, endpointHost the one the hook functions reach it with (host.docker.internal from a container)
+func MockCodeDeploy(filePath string, computePlatform string, host string, port int, endpointHost string, invokeCmd string, hookTimeout time.Duration) {
+	fmt.Println("mockCodeDeploy called on:", filePath, ",", computePlatform)
+
+	appSpecBytes, err := loadAppSpecFile(filePath, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	hookEvents, err := getLifecycleHookEvents(appSpecBytes, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := checkMockCodeDeployHookFunctions(hookEvents); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	eventsDirPath, err := ioutil.TempDir("", "appSpecAssistant-hook-events")
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := writeLifecycleHookEvents(hookEvents, filePath, eventsDirPath); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	mock := newMockCodeDeploy(hookEvents, computePlatform)
+	go http.Serve(listener, mock)
+
+	endpoint := getMockCodeDeployEndpoint(endpointHost, listener.Addr().(*net.TCPAddr).Port)
+	if err := writeMockCodeDeployEnvVars(eventsDirPath, endpoint); err != nil {
+		errorHandling.HandleError(err)
+	}
+	fmt.Printf(errorHandling.MockCodeDeployEndpointMsg, listener.Addr().String(), endpoint)
+
+	deploymentSucceeded := runMockCodeDeployHooks(mock, hookEvents, eventsDirPath, invokeCmd, endpoint, hookTimeout)
+	// HandleError exits, so the events are removed before
+	os.RemoveAll(eventsDirPath)
+
+	fmt.Printf(errorHandling.MockCodeDeployResultsHeaderMsg, mock.deploymentId)
+	for _, hookEvent := range hookEvents {
+		fmt.Printf("%-22s %-12s %s\n", hookEvent.hook.name, mock.getHookStatus(hookEvent.event.LifecycleEventHookExecutionId), hookEvent.hook.function)
+	}
+
+	if !deploymentSucceeded {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.MockCodeDeployFailedErr, mock.deploymentId))
+	}
+
+	fmt.Printf(errorHandling.MockCodeDeploySucceededMsg, mock.deploymentId)
+}
+
+// Placeholders pass validation, but only real function references can be invoked
+func checkMockCodeDeployHookFunctions(hookEvents []lifecycleHookEvent) error {
+	for _, hookEvent := range hookEvents {
+		if isAppSpecPlaceholder(hookEvent.hook.function) || !isValidLambdaFunctionReference(hookEvent.hook.function, true) {
+			return fmt.Errorf(errorHandling.UninvokableMockCodeDeployHookErr, hookEvent.hook.function, hookEvent.hook.name)
+		}
+	}
+
+	return nil
+}
+
+func getMockCodeDeployEndpoint(endpointHost string, port int) string {
+	return "http://" + net.JoinHostPort(endpointHost, strconv.Itoa(port))
+}
+
+// Sets the endpoint for every function of the SAM template, sam only passes variables the function declares in its Environment
+func writeMockCodeDeployEnvVars(eventsDirPath string, endpoint string) error {
+	envVars := map[string]map[string]string{"Parameters": {mockCodeDeployEndpointEnvVar: endpoint}}
+
+	envVarsBytes, err := json.MarshalIndent(envVars, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(eventsDirPath, mockCodeDeployEnvVarsFileName), append(envVarsBytes, '\n'), 0644)
+}
+
+func newMockCodeDeploy(hookEvents []lifecycleHookEvent, computePlatform string) *mockCodeDeploy {
+	mock := &mockCodeDeploy{
+		computePlatform: computePlatform,
+		status:          "InProgress",
+		hookStatuses:    map[string]string{},
+	}
+
+	for _, hookEvent := range hookEvents {
+		mock.deploymentId = hookEvent.event.DeploymentId
+		mock.hookStatuses[hookEvent.event.LifecycleEventHookExecutionId] = hookStatusPending
+	}
+
+	return mock
+}
+
+// Invoke the hook functions in lifecycle order and wait for each to report its status
+// Like CodeDeploy, the deployment stops at the first hook that fails or does not report
+func runMockCodeDeployHooks(mock *mockCodeDeploy, hookEvents []lifecycleHookEvent, eventsDirPath string, invokeCmd string, endpoint string, hookTimeout time.Duration) bool {
+	for i, hookEvent := range hookEvents {
+		executionId := hookEvent.event.LifecycleEventHookExecutionId
+		fmt.Printf(errorHandling.MockCodeDeployInvokingHookMsg, hookEvent.hook.name, hookEvent.hook.function)
+
+		// The values are quoted, the command is run by sh
+		invokeCmdLine := strings.NewReplacer(
+			"{function}", quoteShellArg(getLambdaFunctionName(hookEvent.hook.function)),
+			"{event}", quoteShellArg(filepath.Join(eventsDirPath, hookEvent.eventFileName)),
+			"{endpoint}", quoteShellArg(endpoint),
+			"{envVars}", quoteShellArg(filepath.Join(eventsDirPath, mockCodeDeployEnvVarsFileName)),
+		).Replace(invokeCmd)
+
+		invokeCommand := exec.Command("sh", "-c", invokeCmdLine)
+		invokeCommand.Env = append(os.Environ(), mockCodeDeployEndpointEnvVar+"="+endpoint)
+		invokeCommand.Stdout = os.Stdout
+		invokeCommand.Stderr = os.Stderr
+
+		// The status the hook reported counts, even if the invoke command fails afterwards
+		if err := invokeCommand.Run(); err != nil && mock.getHookStatus(executionId) == hookStatusPending {
+			fmt.Println(errorHandling.MockCodeDeployInvokeFailedErr, hookEvent.hook.name, err)
+			mock.setHookStatus(executionId, hookStatusFailed)
+		}
+
+		status := mock.waitForHookStatus(executionId, hookTimeout)
+		if status == hookStatusPending {
+			fmt.Println(errorHandling.MockCodeDeployHookTimeoutErr, hookEvent.hook.name, hookTimeout)
+			mock.setHookStatus(executionId, hookStatusNoResponse)
+		}
+
+		if status != hookStatusSucceeded {
+			for _, skippedHookEvent := range hookEvents[i+1:] {
+				mock.setHookStatus(skippedHookEvent.event.LifecycleEventHookExecutionId, hookStatusSkipped)
+			}
+			mock.setDeploymentStatus("Failed")
+			return false
+		}
+	}
+
+	mock.setDeploymentStatus("Succeeded")
+
+	return true
+}
+
+// Serve the CodeDeploy JSON API actions by their X-Amz-Target header
+func (mock *mockCodeDeploy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response interface{}
+	var apiErr *mockCodeDeployError
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || r.Method != http.MethodPost {
+		apiErr = &mockCodeDeployError{http.StatusBadRequest, "SerializationException", "expected a POST request with a JSON body"}
+	} else {
+		switch r.Header.Get("X-Amz-Target") {
+		case putLifecycleEventHookExecutionStatusTarget:
+			response, apiErr = mock.putLifecycleEventHookExecutionStatus(body)
+		case getDeploymentTarget:
+			response, apiErr = mock.getDeployment(body)
+		default:
+			apiErr = &mockCodeDeployError{http.StatusBadRequest, "UnknownOperationException", "mock-codedeploy does not support " + r.Header.Get("X-Amz-Target")}
+		}
+	}
+
+	statusCode := http.StatusOK
+	if apiErr != nil {
+		statusCode = apiErr.statusCode
+		response = models.CodeDeployErrorOutput{Type: apiErr.errorType, Message: apiErr.message}
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (mock *mockCodeDeploy) putLifecycleEventHookExecutionStatus(body []byte) (interface{}, *mockCodeDeployError) {
+	var input models.PutLifecycleEventHookExecutionStatusInput
+	if err := json.Unmarshal(body, &input); err != nil {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "SerializationException", err.Error()}
+	}
+
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	if input.DeploymentId != mock.deploymentId {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "DeploymentDoesNotExistException", "deployment " + input.DeploymentId + " does not exist"}
+	}
+
+	currentStatus, ok := mock.hookStatuses[input.LifecycleEventHookExecutionId]
+	if !ok {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "InvalidLifecycleEventHookExecutionIdException", "unknown lifecycleEventHookExecutionId"}
+	}
+
+	if input.Status != hookStatusSucceeded && input.Status != hookStatusFailed {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "InvalidLifecycleEventHookExecutionStatusException", "status must be Succeeded or Failed, got " + input.Status}
+	}
+
+	if currentStatus != hookStatusPending {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "LifecycleEventAlreadyCompletedException", "the hook already completed with status " + currentStatus}
+	}
+
+	mock.hookStatuses[input.LifecycleEventHookExecutionId] = input.Status
+
+	return models.PutLifecycleEventHookExecutionStatusOutput{LifecycleEventHookExecutionId: input.LifecycleEventHookExecutionId}, nil
+}
+
+func (mock *mockCodeDeploy) getDeployment(body []byte) (interface{}, *mockCodeDeployError) {
+	var input models.GetDeploymentInput
+	if err := json.Unmarshal(body, &input); err != nil {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "SerializationException", err.Error()}
+	}
+
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	if input.DeploymentId != mock.deploymentId {
+		return nil, &mockCodeDeployError{http.StatusBadRequest, "DeploymentDoesNotExistException", "deployment " + input.DeploymentId + " does not exist"}
+	}
+
+	// The API uses Lambda and ECS as compute platform names
+	computePlatform := "ECS"
+	if mock.computePlatform == "lambda" {
+		computePlatform = "Lambda"
+	}
+
+	return models.GetDeploymentOutput{
+		DeploymentInfo: models.DeploymentInfo{
+			DeploymentId:        mock.deploymentId,
+			ApplicationName:     mockCodeDeployApplicationName,
+			DeploymentGroupName: mockCodeDeployDeploymentGroupName,
+			Status:              mock.status,
+			ComputePlatform:     computePlatform,
+		},
+	}, nil
+}
+
+// Returns the status once the hook reported it, or Pending after the timeout
+func (mock *mockCodeDeploy) waitForHookStatus(executionId string, timeout time.Duration) string {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		status := mock.getHookStatus(executionId)
+		if status != hookStatusPending || time.Now().After(deadline) {
+			return status
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func (mock *mockCodeDeploy) getHookStatus(executionId string) string {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	return mock.hookStatuses[executionId]
+}
+
+func (mock *mockCodeDeploy) setHookStatus(executionId string, status string) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	mock.hookStatuses[executionId] = status
+}
+
+func (mock *mockCodeDeploy) setDeploymentStatus(status string) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	mock.status = status
+}
@@ -0,0 +1,194 @@
+package assistant
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"encoding/json"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+func getTestMockCodeDeploy(t *testing.T) (*mockCodeDeploy, []lifecycleHookEvent) {
+	fileExtension = "yml"
+	hookEvents, err := getLifecycleHookEvents([]byte(testHookEventsEcsYamlString), "ecs")
+	if err != nil {
+		t.Fatalf("getLifecycleHookEvents FAILED: %v", err)
+	}
+
+	return newMockCodeDeploy(hookEvents, "ecs"), hookEvents
+}
+
+func postMockCodeDeploy(t *testing.T, endpoint string, target string, input interface{}) (int, string) {
+	body, _ := json.Marshal(input)
+	request, _ := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	request.Header.Set("X-Amz-Target", target)
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Request to the mock CodeDeploy API FAILED: %v", err)
+	}
+	defer response.Body.Close()
+	responseBody, _ := ioutil.ReadAll(response.Body)
+
+	return response.StatusCode, string(responseBody)
+}
+
+// Test the PutLifecycleEventHookExecutionStatus and GetDeployment actions
+func TestMockCodeDeploy_Api(t *testing.T) {
+	mock, hookEvents := getTestMockCodeDeploy(t)
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	deploymentId := hookEvents[0].event.DeploymentId
+	executionId := hookEvents[0].event.LifecycleEventHookExecutionId
+
+	var tests = []struct {
+		name               string
+		target             string
+		input              interface{}
+		expectedStatusCode int
+		expectedBodySubStr string
+	}{
+		{"Hook succeeded", putLifecycleEventHookExecutionStatusTarget,
+			models.PutLifecycleEventHookExecutionStatusInput{DeploymentId: deploymentId, LifecycleEventHookExecutionId: executionId, Status: "Succeeded"},
+			http.StatusOK, executionId},
+		{"Hook reports twice", putLifecycleEventHookExecutionStatusTarget,
+			models.PutLifecycleEventHookExecutionStatusInput{DeploymentId: deploymentId, LifecycleEventHookExecutionId: executionId, Status: "Failed"},
+			http.StatusBadRequest, "LifecycleEventAlreadyCompletedException"},
+		{"Invalid status", putLifecycleEventHookExecutionStatusTarget,
+			models.PutLifecycleEventHookExecutionStatusInput{DeploymentId: deploymentId, LifecycleEventHookExecutionId: hookEvents[1].event.LifecycleEventHookExecutionId, Status: "Done"},
+			http.StatusBadRequest, "InvalidLifecycleEventHookExecutionStatusException"},
+		{"Unknown execution ID", putLifecycleEventHookExecutionStatusTarget,
+			models.PutLifecycleEventHookExecutionStatusInput{DeploymentId: deploymentId, LifecycleEventHookExecutionId: "unknown", Status: "Succeeded"},
+			http.StatusBadRequest, "InvalidLifecycleEventHookExecutionIdException"},
+		{"Unknown deployment", getDeploymentTarget,
+			models.GetDeploymentInput{DeploymentId: "d-UNKNOWN00"},
+			http.StatusBadRequest, "DeploymentDoesNotExistException"},
+		{"Get deployment", getDeploymentTarget,
+			models.GetDeploymentInput{DeploymentId: deploymentId},
+			http.StatusOK, `"status":"InProgress","computePlatform":"ECS"`},
+		{"Unsupported action", "CodeDeploy_20141006.CreateDeployment",
+			models.GetDeploymentInput{DeploymentId: deploymentId},
+			http.StatusBadRequest, "UnknownOperationException"},
+	}
+
+	for _, test := range tests {
+		statusCode, body := postMockCodeDeploy(t, server.URL, test.target, test.input)
+		if statusCode != test.expectedStatusCode || !strings.Contains(body, test.expectedBodySubStr) {
+			t.Errorf("The mock CodeDeploy API returned %v %v for: %v", statusCode, body, test.name)
+		}
+	}
+
+	if status := mock.getHookStatus(executionId); status != hookStatusSucceeded {
+		t.Errorf("The mock CodeDeploy API recorded the status %v instead of Succeeded", status)
+	}
+}
+
+// Test runMockCodeDeployHooks
+func TestRunMockCodeDeployHooks(t *testing.T) {
+	mock, hookEvents := getTestMockCodeDeploy(t)
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	// The first hook already reported Succeeded, the second does not report, the third never runs
+	mock.setHookStatus(hookEvents[0].event.LifecycleEventHookExecutionId, hookStatusSucceeded)
+
+	if runMockCodeDeployHooks(mock, hookEvents, "", "true", server.URL, 100*time.Millisecond) {
+		t.Errorf("The runMockCodeDeployHooks function succeeded although a hook did not report its status")
+	}
+
+	expectedStatuses := []string{hookStatusSucceeded, hookStatusNoResponse, hookStatusSkipped}
+	for i, hookEvent := range hookEvents {
+		if status := mock.getHookStatus(hookEvent.event.LifecycleEventHookExecutionId); status != expectedStatuses[i] {
+			t.Errorf("The runMockCodeDeployHooks function set the status %v instead of %v for: %v", status, expectedStatuses[i], hookEvent.hook.name)
+		}
+	}
+
+	if mock.status != "Failed" {
+		t.Errorf("The runMockCodeDeployHooks function set the deployment status %v instead of Failed", mock.status)
+	}
+}
+
+func TestRunMockCodeDeployHooks_InvokeCommandFails(t *testing.T) {
+	mock, hookEvents := getTestMockCodeDeploy(t)
+
+	if runMockCodeDeployHooks(mock, hookEvents, "", "exit 1", "http://127.0.0.1:0", time.Minute) {
+		t.Errorf("The runMockCodeDeployHooks function succeeded although the invoke command failed")
+	}
+
+	if status := mock.getHookStatus(hookEvents[0].event.LifecycleEventHookExecutionId); status != hookStatusFailed {
+		t.Errorf("The runMockCodeDeployHooks function set the status %v instead of Failed", status)
+	}
+}
+
+// Test writeMockCodeDeployEnvVars and the {endpoint} and {envVars} substitutions
+func TestRunMockCodeDeployHooks_EnvVarsFile(t *testing.T) {
+	mock, hookEvents := getTestMockCodeDeploy(t)
+
+	eventsDirPath, err := ioutil.TempDir("", "appSpecAssistant-test")
+	if err != nil {
+		t.Fatalf("TempDir FAILED: %v", err)
+	}
+	defer os.RemoveAll(eventsDirPath)
+
+	endpoint := getMockCodeDeployEndpoint("host.docker.internal", 4599)
+	if endpoint != "http://host.docker.internal:4599" {
+		t.Errorf("The getMockCodeDeployEndpoint function returned %v", endpoint)
+	}
+
+	if err := writeMockCodeDeployEnvVars(eventsDirPath, endpoint); err != nil {
+		t.Fatalf("The writeMockCodeDeployEnvVars function FAILED: %v", err)
+	}
+
+	var envVars map[string]map[string]string
+	envVarsBytes, _ := ioutil.ReadFile(filepath.Join(eventsDirPath, mockCodeDeployEnvVarsFileName))
+	if err := json.Unmarshal(envVarsBytes, &envVars); err != nil || envVars["Parameters"][mockCodeDeployEndpointEnvVar] != endpoint {
+		t.Errorf("The writeMockCodeDeployEnvVars function wrote %s", envVarsBytes)
+	}
+
+	// The invoke command only succeeds when both are replaced, the hook then does not report instead of failing
+	invokeCmd := `grep -q {endpoint} {envVars} && test "$AWS_ENDPOINT_URL_CODEDEPLOY" = {endpoint}`
+	runMockCodeDeployHooks(mock, hookEvents, eventsDirPath, invokeCmd, endpoint, 50*time.Millisecond)
+	if status := mock.getHookStatus(hookEvents[0].event.LifecycleEventHookExecutionId); status != hookStatusNoResponse {
+		t.Errorf("The runMockCodeDeployHooks function set the status %v instead of %v", status, hookStatusNoResponse)
+	}
+}
+
+// Test checkMockCodeDeployHookFunctions and that the substituted values are quoted for sh
+func TestRunMockCodeDeployHooks_HookFunctions(t *testing.T) {
+	var tests = []struct {
+		name     string
+		function string
+		valid    bool
+	}{
+		{"Function name", "CodeDeployHook_BeforeInstall", true},
+		{"Function ARN with alias", "arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeInstall:live", true},
+		{"Placeholder", "BeforeInstallHookLambdaFunctionName", false},
+		{"Bracket placeholder with a command", "[x $(touch pwned)]", false},
+		{"Invalid function name", "my hook; touch pwned", false},
+	}
+
+	for _, test := range tests {
+		hookEvents := []lifecycleHookEvent{{hook: appSpecHook{name: "BeforeInstall", function: test.function}}}
+		if err := checkMockCodeDeployHookFunctions(hookEvents); (err == nil) != test.valid {
+			t.Errorf("The checkMockCodeDeployHookFunctions function returned %v for: %v", err, test.name)
+		}
+	}
+
+	// The endpoint can not end the quoting of the invoke command
+	mock, hookEvents := getTestMockCodeDeploy(t)
+	endpoint := "http://127.0.0.1:1'; exit 1; '"
+	runMockCodeDeployHooks(mock, hookEvents, "", `test {endpoint} = "$AWS_ENDPOINT_URL_CODEDEPLOY"`, endpoint, 50*time.Millisecond)
+	if status := mock.getHookStatus(hookEvents[0].event.LifecycleEventHookExecutionId); status != hookStatusNoResponse {
+		t.Errorf("The runMockCodeDeployHooks function did not quote the endpoint, the hook status is %v", status)
+	}
+}
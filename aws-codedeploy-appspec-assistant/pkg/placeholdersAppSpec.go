@@ -0,0 +1,184 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+)
+
+// Set by the validate command
+// Placeholders are substituted from the values file first, then from environment variables
+var SubstituteAppSpecVariables bool
+var AppSpecValuesFilePath string
+
+// ${VAR} and {{ .Var }} placeholders
+var appSpecVariablePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`),
+	regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`),
+}
+
+// Values like "[Your task definition arn]" and "<SanityTestHookLambdaFunctionName>" from the templates
+var appSpecTemplatePlaceholderPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\[.+\]$`),
+	regexp.MustCompile(`^<[^<>]+>$`),
+}
+
+// Substitute the placeholders of the AppSpec
+// Returns the AppSpec content that should be validated afterwards
+func applyAppSpecVariables(appSpecBytes []byte) ([]byte, error) {
+	values := map[string]string{}
+
+	if AppSpecValuesFilePath != "" {
+		valuesBytes, err := ioutil.ReadFile(AppSpecValuesFilePath)
+		if err != nil {
+			return appSpecBytes, err
+		}
+		if err := yaml.Unmarshal(valuesBytes, &values); err != nil {
+			return appSpecBytes, fmt.Errorf(errorHandling.InvalidValuesFileErr, AppSpecValuesFilePath, err)
+		}
+	}
+
+	return substituteAppSpecVariables(appSpecBytes, values)
+}
+
+// Substitute ${VAR} and {{ .Var }} placeholders in the keys and values of the AppSpec
+// Works on the YAML node tree so placeholders in comments are left alone
+func substituteAppSpecVariables(appSpecBytes []byte, values map[string]string) ([]byte, error) {
+	appSpecNode, err := getAppSpecNodeFromString(appSpecBytes)
+	if err != nil {
+		return appSpecBytes, err
+	}
+
+	unresolvedVariables := map[string]bool{}
+	numOfSubstitutions := 0
+
+	walkAppSpecScalars(appSpecNode, "", true, func(scalarNode *yaml.Node, path string) {
+		substitutedValue := scalarNode.Value
+		for _, variablePattern := range appSpecVariablePatterns {
+			substitutedValue = variablePattern.ReplaceAllStringFunc(substitutedValue, func(placeholder string) string {
+				name := variablePattern.FindStringSubmatch(placeholder)[1]
+				if value, ok := values[name]; ok {
+					return value
+				}
+				if value, ok := os.LookupEnv(name); ok {
+					return value
+				}
+				unresolvedVariables[name] = true
+				return placeholder
+			})
+		}
+
+		if substitutedValue != scalarNode.Value {
+			numOfSubstitutions++
+			scalarNode.Value = substitutedValue
+			// Unquoted values get the type of the substituted value (ContainerPort: ${PORT} is a number)
+			if scalarNode.Style == 0 {
+				scalarNode.Tag = ""
+			}
+		}
+	})
+
+	if len(unresolvedVariables) > 0 {
+		var names []string
+		for name := range unresolvedVariables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		numOfErrors++
+		fmt.Println(errorHandling.UnresolvedAppSpecVariablesErr, strings.Join(names, ", "))
+		return appSpecBytes, fmt.Errorf(errorHandling.InvalidAppSpecVariablesErr)
+	}
+
+	if numOfSubstitutions < 1 {
+		return appSpecBytes, nil
+	}
+
+	fmt.Printf(errorHandling.SubstitutedAppSpecVariablesMsg, numOfSubstitutions)
+
+	return getStringFromAppSpecNode(appSpecNode)
+}
+
+// Detect values that were never filled in: template placeholders and variables that were not substituted
+// Returns false if there are any
+func validateAppSpecPlaceholders(appSpecBytes []byte) bool {
+	appSpecNode, err := getAppSpecNodeFromString(appSpecBytes)
+	if err != nil {
+		// Syntax errors are reported by the model conversion
+		return true
+	}
+
+	placeholdersValid := true
+
+	walkAppSpecScalars(appSpecNode, "", false, func(scalarNode *yaml.Node, path string) {
+		if isAppSpecPlaceholder(scalarNode.Value) {
+			placeholdersValid = false
+			numOfErrors++
+			fmt.Println(errorHandling.UnfilledAppSpecPlaceholderErr, fmt.Sprintf("line %d: %s: %s", scalarNode.Line, path, scalarNode.Value))
+		}
+	})
+
+	return placeholdersValid
+}
+
+func isAppSpecPlaceholder(value string) bool {
+	// CodePipeline fills in the task definition
+	if EcsPipelineMode && value == globalVars.EcsPipelineTaskDefinitionPlaceholder {
+		return false
+	}
+
+	for _, templatePlaceholderValue := range globalVars.AppSpecTemplatePlaceholderValues {
+		if value == templatePlaceholderValue {
+			return true
+		}
+	}
+
+	for _, placeholderPattern := range appSpecTemplatePlaceholderPatterns {
+		if placeholderPattern.MatchString(value) {
+			return true
+		}
+	}
+
+	for _, variablePattern := range appSpecVariablePatterns {
+		if variablePattern.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Call visit on every scalar of the node tree with its path (Resources[0].TargetService.Type)
+// Mapping keys are only visited if withKeys is set
+func walkAppSpecScalars(node *yaml.Node, path string, withKeys bool, visit func(*yaml.Node, string)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, contentNode := range node.Content {
+			walkAppSpecScalars(contentNode, path, withKeys, visit)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := node.Content[i].Value
+			if path != "" {
+				keyPath = path + "." + keyPath
+			}
+			if withKeys {
+				visit(node.Content[i], keyPath)
+			}
+			walkAppSpecScalars(node.Content[i+1], keyPath, withKeys, visit)
+		}
+	case yaml.SequenceNode:
+		for i, itemNode := range node.Content {
+			walkAppSpecScalars(itemNode, fmt.Sprintf("%s[%d]", path, i), withKeys, visit)
+		}
+	case yaml.ScalarNode:
+		visit(node, path)
+	}
+}
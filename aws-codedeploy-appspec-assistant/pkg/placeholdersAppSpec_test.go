@@ -0,0 +1,91 @@
+package assistant
+
+import (
+	"os"
+	"testing"
+)
+
+// Test substituteAppSpecVariables
+func TestSubstituteAppSpecVariables_ResolvedInput(t *testing.T) {
+	os.Setenv("APPSPEC_ASSISTANT_TEST_PORT", "8080")
+	defer os.Unsetenv("APPSPEC_ASSISTANT_TEST_PORT")
+
+	var tests = []struct {
+		name             string
+		appSpecInput     string
+		fileExtensionVal string
+		valuesInput      map[string]string
+		expectedOutput   string
+	}{
+		{"YAML from values and environment",
+			"version: 0.0\n# ${IN_COMMENT}\nTaskDefinition: \"${TASK_DEF}:3\"\nContainerName: \"{{ .Container }}\"\nContainerPort: ${APPSPEC_ASSISTANT_TEST_PORT}\n",
+			"yml",
+			map[string]string{"TASK_DEF": "web", "Container": "app"},
+			"version: 0.0\n# ${IN_COMMENT}\nTaskDefinition: \"web:3\"\nContainerName: \"app\"\nContainerPort: 8080\n"},
+
+		{"JSON keeps quoted values as strings",
+			`{"version": 0.0, "CurrentVersion": "{{.Current}}"}`,
+			"json",
+			map[string]string{"Current": "1"},
+			"{\n  \"version\": 0.0,\n  \"CurrentVersion\": \"1\"\n}\n"},
+
+		{"Nothing to substitute",
+			"version: 0.0\nos: linux\n",
+			"yml",
+			map[string]string{},
+			"version: 0.0\nos: linux\n"},
+	}
+
+	for _, test := range tests {
+		fileExtension = test.fileExtensionVal
+		output, err := substituteAppSpecVariables([]byte(test.appSpecInput), test.valuesInput)
+		if err != nil || string(output) != test.expectedOutput {
+			t.Errorf("The substituteAppSpecVariables function did not substitute correctly for: %v. Got: %v %v", test.name, string(output), err)
+		}
+	}
+}
+
+func TestSubstituteAppSpecVariables_UnresolvedInput(t *testing.T) {
+	fileExtension = "yml"
+	_, err := substituteAppSpecVariables([]byte("version: 0.0\nTaskDefinition: ${APPSPEC_ASSISTANT_TEST_UNSET}\n"), map[string]string{})
+	if err == nil {
+		t.Errorf("The substituteAppSpecVariables function did not fail for an unresolved placeholder")
+	}
+}
+
+// Test validateAppSpecPlaceholders
+func TestValidateAppSpecPlaceholders_ValidInput(t *testing.T) {
+	var tests = []struct {
+		name         string
+		appSpecInput string
+	}{
+		{"Filled out ECS",
+			"version: 0.0\nResources:\n  - TargetService:\n      Properties:\n        TaskDefinition: arn:aws:ecs:us-east-1:111122223333:task-definition/web:3\nHooks:\n  - BeforeInstall: CodeDeployHook_BeforeInstall\n"},
+		{"Filled out Server",
+			"version: 0.0\nos: linux\nfiles:\n  - source: /\n    destination: /var/www/html\n"},
+	}
+
+	for _, test := range tests {
+		if !validateAppSpecPlaceholders([]byte(test.appSpecInput)) {
+			t.Errorf("The validateAppSpecPlaceholders function failed for: %v", test.name)
+		}
+	}
+}
+
+func TestValidateAppSpecPlaceholders_InvalidInput(t *testing.T) {
+	var tests = []struct {
+		name         string
+		appSpecInput string
+	}{
+		{"ECS template", ecsYamlString},
+		{"Lambda template", lambdaJsonString},
+		{"Server template", serverYamlString},
+		{"Variable that was not substituted", "version: 0.0\nos: ${OS}\n"},
+	}
+
+	for _, test := range tests {
+		if validateAppSpecPlaceholders([]byte(test.appSpecInput)) {
+			t.Errorf("The validateAppSpecPlaceholders function succeeded but should have failed for: %v", test.name)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package assistant
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+)
+
+// Main function of the plan-files command
+func PlanServerFiles(revisionPath string, stagingDirPath string) {
+	fmt.Println("planServerFiles called on:", revisionPath)
+
+	revision, err := loadAppSpecRevision(revisionPath)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	appSpecBytes, err := revision.readAppSpec()
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	serverAppSpecModel, err := getServerAppSpecObjFromString(appSpecBytes)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if len(serverAppSpecModel.Files) < 1 {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.MissingServerFileSpecErr))
+	}
+	if !validateServerFiles(serverAppSpecModel.Files) {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.InvalidServerFileSpecsErr))
+	}
+
+	fileCopies, err := resolveServerFiles(serverAppSpecModel.Files, revision.getFiles())
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	fmt.Println()
+	for i, file := range serverAppSpecModel.Files {
+		kind, numOfFiles := getServerFileSourceKind(file.Source, i, fileCopies)
+		fmt.Printf(errorHandling.PlanServerFilesSourceMsg, i, file.Source, file.Destination, kind, numOfFiles)
+	}
+
+	fmt.Printf(errorHandling.PlanServerFilesTreeMsg, len(fileCopies))
+	for _, line := range getServerFilesTree(fileCopies) {
+		fmt.Println(line)
+	}
+
+	for _, duplicate := range getDuplicateServerFileDestinations(fileCopies) {
+		fmt.Printf(errorHandling.DuplicateServerFileDestinationWarn, duplicate.destination, duplicate.sources)
+	}
+
+	if stagingDirPath != "" {
+		for _, fileCopy := range fileCopies {
+			if err := revision.copyFile(fileCopy.source, stagingDirPath, getSandboxPath(stagingDirPath, fileCopy.destination)); err != nil {
+				errorHandling.HandleError(err)
+			}
+		}
+		fmt.Printf(errorHandling.StagedServerFilesMsg, len(fileCopies), stagingDirPath)
+	}
+}
+
+// How a files source expands: a single file, the content of a directory, or the whole revision
+func getServerFileSourceKind(source string, fileIndex int, fileCopies []serverFileCopy) (string, int) {
+	trimmedSource := strings.Trim(strings.Replace(source, "\\", "/", -1), "/")
+	kind := "directory"
+	numOfFiles := 0
+
+	for _, fileCopy := range fileCopies {
+		if fileCopy.fileIndex != fileIndex {
+			continue
+		}
+		numOfFiles++
+		if fileCopy.source == trimmedSource {
+			kind = "file"
+		}
+	}
+
+	if trimmedSource == "" {
+		kind = "whole revision, including the AppSpec"
+	}
+
+	return kind, numOfFiles
+}
+
+// Print the destinations as an indented tree with the revision file each comes from
+func getServerFilesTree(fileCopies []serverFileCopy) []string {
+	sources := map[string][]string{}
+	var destinations []string
+
+	for _, fileCopy := range fileCopies {
+		destination := strings.Replace(fileCopy.destination, "\\", "/", -1)
+		if _, ok := sources[destination]; !ok {
+			destinations = append(destinations, destination)
+		}
+		sources[destination] = append(sources[destination], fmt.Sprintf("%s (files[%d])", fileCopy.source, fileCopy.fileIndex))
+	}
+	sort.Strings(destinations)
+
+	var lines []string
+	var printedDirs []string
+
+	for _, destination := range destinations {
+		parts := strings.Split(strings.TrimPrefix(destination, "/"), "/")
+		root := ""
+		if strings.HasPrefix(destination, "/") {
+			root = "/"
+		}
+
+		// Directories that are not shared with the previous destination
+		for depth := range parts[:len(parts)-1] {
+			dir := root + strings.Join(parts[:depth+1], "/")
+			if depth < len(printedDirs) && printedDirs[depth] == dir {
+				continue
+			}
+			printedDirs = append(printedDirs[:depth], dir)
+
+			name := parts[depth] + "/"
+			if depth == 0 {
+				name = root + name
+			}
+			lines = append(lines, strings.Repeat("  ", depth)+name)
+		}
+
+		depth := len(parts) - 1
+		lines = append(lines, fmt.Sprintf("%s%s <- %s", strings.Repeat("  ", depth), parts[depth], strings.Join(sources[destination], ", ")))
+	}
+
+	return lines
+}
+
+type serverFileDestinationDuplicate struct {
+	destination string
+	sources     []string
+}
+
+// Destinations more than one revision file is copied to
+func getDuplicateServerFileDestinations(fileCopies []serverFileCopy) []serverFileDestinationDuplicate {
+	var duplicates []serverFileDestinationDuplicate
+	sources := map[string][]string{}
+	var destinations []string
+
+	for _, fileCopy := range fileCopies {
+		if _, ok := sources[fileCopy.destination]; !ok {
+			destinations = append(destinations, fileCopy.destination)
+		}
+		sources[fileCopy.destination] = append(sources[fileCopy.destination], fileCopy.source)
+	}
+
+	for _, destination := range destinations {
+		if len(sources[destination]) > 1 {
+			duplicates = append(duplicates, serverFileDestinationDuplicate{destination, sources[destination]})
+		}
+	}
+
+	return duplicates
+}
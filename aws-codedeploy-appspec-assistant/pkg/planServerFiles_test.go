@@ -0,0 +1,63 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+)
+
+var testServerFileCopies = []serverFileCopy{
+	{"app/index.html", "/var/www/index.html", 0},
+	{"app/static/app.js", "/var/www/static/app.js", 0},
+	{"config/app.conf", "/etc/app/app.conf", 1},
+	{"config/index.html", "/var/www/index.html", 2},
+}
+
+// Test getServerFilesTree
+func TestGetServerFilesTree(t *testing.T) {
+	expectedTree := strings.Join([]string{
+		"/etc/",
+		"  app/",
+		"    app.conf <- config/app.conf (files[1])",
+		"/var/",
+		"  www/",
+		"    index.html <- app/index.html (files[0]), config/index.html (files[2])",
+		"    static/",
+		"      app.js <- app/static/app.js (files[0])",
+	}, "\n")
+
+	if tree := strings.Join(getServerFilesTree(testServerFileCopies), "\n"); tree != expectedTree {
+		t.Errorf("The getServerFilesTree function returned:\n%v\ninstead of:\n%v", tree, expectedTree)
+	}
+}
+
+// Test getDuplicateServerFileDestinations
+func TestGetDuplicateServerFileDestinations(t *testing.T) {
+	duplicates := getDuplicateServerFileDestinations(testServerFileCopies)
+	if len(duplicates) != 1 || duplicates[0].destination != "/var/www/index.html" || len(duplicates[0].sources) != 2 {
+		t.Errorf("The getDuplicateServerFileDestinations function returned %v", duplicates)
+	}
+
+	if duplicates := getDuplicateServerFileDestinations(testServerFileCopies[:3]); len(duplicates) != 0 {
+		t.Errorf("The getDuplicateServerFileDestinations function returned %v for unique destinations", duplicates)
+	}
+}
+
+// Test getServerFileSourceKind
+func TestGetServerFileSourceKind(t *testing.T) {
+	var tests = []struct {
+		source        string
+		fileIndex     int
+		expectedKind  string
+		expectedFiles int
+	}{
+		{"app", 0, "directory", 2},
+		{"/config/app.conf", 1, "file", 1},
+		{"/", 0, "whole revision, including the AppSpec", 2},
+	}
+
+	for _, test := range tests {
+		if kind, numOfFiles := getServerFileSourceKind(test.source, test.fileIndex, testServerFileCopies); kind != test.expectedKind || numOfFiles != test.expectedFiles {
+			t.Errorf("The getServerFileSourceKind function returned %v, %d instead of %v, %d for: %v", kind, numOfFiles, test.expectedKind, test.expectedFiles, test.source)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+)
+
+// Lists that are merged item by item instead of being replaced by the overlay
+// Every other list in the overlay (Subnets, SecurityGroups, scripts, ...) replaces the base list
+var strategicMergeListKeys = map[string]func(*yaml.Node) string{
+	"Resources":   getSingleKeyMergeKey,
+	"Hooks":       getSingleKeyMergeKey,
+	"files":       getServerFileMergeKey,
+	"permissions": getServerPermissionMergeKey,
+}
+
+// List items with this key set to delete are removed from the base list
+const strategicMergePatchKey = "$patch"
+
+// Main function of the render command
+func RenderAppSpec(baseFilePath string, overlayFilePaths []string, outFilePath string, computePlatform string) {
+	fmt.Println("renderAppSpec called on:", baseFilePath, ",", overlayFilePaths, ",", computePlatform)
+
+	renderedAppSpec, err := renderAppSpecFiles(baseFilePath, overlayFilePaths, outFilePath, computePlatform)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if validationErr := runValidation(renderedAppSpec, computePlatform); validationErr != nil {
+		fmt.Println(errorHandling.InvalidRenderedAppSpecErr)
+		errorHandling.HandleError(validationErr)
+	}
+
+	if err := ioutil.WriteFile(outFilePath, renderedAppSpec, 0644); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	fmt.Println("Rendered AppSpec file has passed available validation checks and was written to:", outFilePath)
+}
+
+// Merge the overlays into the base AppSpec in order
+// Returns the rendered AppSpec in the format of the out file
+func renderAppSpecFiles(baseFilePath string, overlayFilePaths []string, outFilePath string, computePlatform string) ([]byte, error) {
+	if !isValidFileNameAndExtension(outFilePath) {
+		return nil, fmt.Errorf(errorHandling.InvalidFileNameOrExtensionErr)
+	}
+
+	baseAppSpecBytes, err := loadAppSpecFile(baseFilePath, computePlatform)
+	if err != nil {
+		return nil, err
+	}
+
+	appSpecNode, err := getAppSpecNodeFromString(baseAppSpecBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overlayFilePath := range overlayFilePaths {
+		overlayBytes, err := ioutil.ReadFile(overlayFilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		overlayNode, err := getAppSpecNodeFromString(overlayBytes)
+		if err != nil {
+			return nil, fmt.Errorf(errorHandling.InvalidOverlayFileErr, overlayFilePath, err)
+		}
+
+		appSpecNode.Content[0] = mergeAppSpecNodes(appSpecNode.Content[0], overlayNode.Content[0], "")
+	}
+
+	// The out file decides between JSON and YAML
+	saveFileExtension(outFilePath)
+
+	return getStringFromAppSpecNode(appSpecNode)
+}
+
+// Strategic merge of an overlay node into a base node
+// Mappings are merged key by key (a null value removes the key)
+// Lists in strategicMergeListKeys are merged by item, everything else is replaced by the overlay
+func mergeAppSpecNodes(baseNode *yaml.Node, overlayNode *yaml.Node, key string) *yaml.Node {
+	if baseNode == nil {
+		return overlayNode
+	}
+
+	if baseNode.Kind == yaml.MappingNode && overlayNode.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(overlayNode.Content); i += 2 {
+			overlayKeyNode := overlayNode.Content[i]
+			overlayValueNode := overlayNode.Content[i+1]
+
+			baseIndex := getMappingKeyIndex(baseNode, overlayKeyNode.Value)
+			if overlayValueNode.ShortTag() == "!!null" {
+				if baseIndex >= 0 {
+					baseNode.Content = append(baseNode.Content[:baseIndex], baseNode.Content[baseIndex+2:]...)
+				}
+			} else if baseIndex >= 0 {
+				baseNode.Content[baseIndex+1] = mergeAppSpecNodes(baseNode.Content[baseIndex+1], overlayValueNode, overlayKeyNode.Value)
+			} else {
+				baseNode.Content = append(baseNode.Content, overlayKeyNode, overlayValueNode)
+			}
+		}
+		return baseNode
+	}
+
+	getMergeKey, isStrategicList := strategicMergeListKeys[key]
+	if isStrategicList && baseNode.Kind == yaml.SequenceNode && overlayNode.Kind == yaml.SequenceNode {
+		for _, overlayItemNode := range overlayNode.Content {
+			mergeKey := getMergeKey(overlayItemNode)
+			baseItemIndex := -1
+			for i, baseItemNode := range baseNode.Content {
+				if mergeKey != "" && getMergeKey(baseItemNode) == mergeKey {
+					baseItemIndex = i
+					break
+				}
+			}
+
+			if isDeleteListItemPatch(overlayItemNode) {
+				if baseItemIndex >= 0 {
+					baseNode.Content = append(baseNode.Content[:baseItemIndex], baseNode.Content[baseItemIndex+1:]...)
+				}
+			} else if baseItemIndex >= 0 {
+				baseNode.Content[baseItemIndex] = mergeAppSpecNodes(baseNode.Content[baseItemIndex], overlayItemNode, "")
+			} else {
+				baseNode.Content = append(baseNode.Content, overlayItemNode)
+			}
+		}
+		return baseNode
+	}
+
+	// Keep the comments of the base file on replaced values
+	if overlayNode.HeadComment == "" && overlayNode.LineComment == "" {
+		overlayNode.HeadComment = baseNode.HeadComment
+		overlayNode.LineComment = baseNode.LineComment
+	}
+
+	return overlayNode
+}
+
+func getMappingKeyIndex(mappingNode *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// A list item is removed by the overlay with `$patch: delete`,
+// or for single key items (Resources and Hooks) with a null value: `- BeforeInstall: null`
+func isDeleteListItemPatch(itemNode *yaml.Node) bool {
+	if patchNode := getMappingValueNode(itemNode, strategicMergePatchKey); patchNode != nil {
+		return patchNode.Value == "delete"
+	}
+
+	return itemNode.Kind == yaml.MappingNode && len(itemNode.Content) == 2 && itemNode.Content[1].ShortTag() == "!!null"
+}
+
+// Resources and Hooks items are single key mappings ([{TargetService: ...}], [{BeforeInstall: ...}])
+func getSingleKeyMergeKey(itemNode *yaml.Node) string {
+	if itemNode.Kind != yaml.MappingNode || len(itemNode.Content) < 2 {
+		return ""
+	}
+
+	return itemNode.Content[0].Value
+}
+
+func getServerFileMergeKey(itemNode *yaml.Node) string {
+	if sourceNode := getMappingValueNode(itemNode, "source"); sourceNode != nil {
+		return sourceNode.Value
+	}
+
+	return ""
+}
+
+func getServerPermissionMergeKey(itemNode *yaml.Node) string {
+	objectNode := getMappingValueNode(itemNode, "object")
+	if objectNode == nil {
+		return ""
+	}
+
+	if patternNode := getMappingValueNode(itemNode, "pattern"); patternNode != nil {
+		return objectNode.Value + " " + patternNode.Value
+	}
+
+	return objectNode.Value
+}
@@ -0,0 +1,56 @@
+package assistant
+
+import (
+	"testing"
+)
+
+// Test mergeAppSpecNodes
+func TestMergeAppSpecNodes(t *testing.T) {
+	var tests = []struct {
+		name           string
+		baseInput      string
+		overlayInput   string
+		expectedOutput string
+	}{
+		{"Scalars are replaced and keys are added",
+			"version: 0.0\nos: linux\n",
+			"os: windows\nfiles: []\n",
+			"version: 0.0\nos: windows\nfiles: []\n"},
+
+		{"Null removes a key",
+			"version: 0.0\npermissions:\n- object: /var/www\n",
+			"permissions: null\n",
+			"version: 0.0\n"},
+
+		{"ECS resources are merged by TargetService and other lists are replaced",
+			"Resources:\n- TargetService:\n    Type: AWS::ECS::Service\n    Properties:\n      TaskDefinition: dev\n      NetworkConfiguration:\n        AwsvpcConfiguration:\n          Subnets: [subnet-dev1, subnet-dev2]\n",
+			"Resources:\n- TargetService:\n    Properties:\n      TaskDefinition: prod\n      NetworkConfiguration:\n        AwsvpcConfiguration:\n          Subnets: [subnet-prod1]\n",
+			"Resources:\n- TargetService:\n    Type: AWS::ECS::Service\n    Properties:\n      TaskDefinition: prod\n      NetworkConfiguration:\n        AwsvpcConfiguration:\n          Subnets: [subnet-prod1]\n"},
+
+		{"Hooks are merged by hook name",
+			"Hooks:\n- BeforeInstall: devBefore\n- AfterInstall: devAfter\n",
+			"Hooks:\n- AfterInstall: prodAfter\n- BeforeInstall: null\n- AfterAllowTraffic: prodValidate\n",
+			"Hooks:\n- AfterInstall: prodAfter\n- AfterAllowTraffic: prodValidate\n"},
+
+		{"files are merged by source and permissions by object",
+			"files:\n- source: app\n  destination: /var/www\n- source: dev-config\n  destination: /etc/app\npermissions:\n- object: /var/www\n  owner: dev\n  mode: 755\n",
+			"files:\n- source: app\n  destination: /opt/www\n- source: dev-config\n  $patch: delete\npermissions:\n- object: /var/www\n  owner: prod\n",
+			"files:\n- source: app\n  destination: /opt/www\npermissions:\n- object: /var/www\n  owner: prod\n  mode: 755\n"},
+	}
+
+	for _, test := range tests {
+		fileExtension = "yml"
+		baseNode, baseErr := getAppSpecNodeFromString([]byte(test.baseInput))
+		overlayNode, overlayErr := getAppSpecNodeFromString([]byte(test.overlayInput))
+		if baseErr != nil || overlayErr != nil {
+			t.Errorf("getAppSpecNodeFromString FAILED for: %v", test.name)
+			continue
+		}
+
+		baseNode.Content[0] = mergeAppSpecNodes(baseNode.Content[0], overlayNode.Content[0], "")
+		output, err := getStringFromAppSpecNode(baseNode)
+		if err != nil || string(output) != test.expectedOutput {
+			t.Errorf("The mergeAppSpecNodes function did not merge correctly for: %v. Got:\n%v", test.name, string(output))
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"encoding/json"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Set by the validate command
+// Server only: the kind of deployment the hooks are validated for, directly or from saved get-deployment-group output
+var ServerDeploymentType string
+var ServerWithLoadBalancer bool
+var ServerDeploymentGroupFilePath string
+
+// The kind of EC2/On-Prem deployment
+type serverDeploymentConfig struct {
+	deploymentType   string // in-place or blue-green
+	withLoadBalancer bool
+}
+
+// Instances of a deployment and the hooks that run on them, in lifecycle order
+type serverHookTarget struct {
+	instances string
+	hooks     []string
+}
+
+// Deployment the server hooks are validated for, nil if none was set
+var serverDeployment *serverDeploymentConfig
+
+// Set up the deployment from the options, which take precedence over the deployment group file
+func setupServerDeployment() error {
+	serverDeployment = nil
+
+	if ServerDeploymentType == "" && !ServerWithLoadBalancer && ServerDeploymentGroupFilePath == "" {
+		return nil
+	}
+
+	deployment := serverDeploymentConfig{deploymentType: "in-place"}
+	if ServerDeploymentGroupFilePath != "" {
+		var err error
+		if deployment, err = loadServerDeploymentGroupFile(ServerDeploymentGroupFilePath); err != nil {
+			return err
+		}
+	}
+
+	if ServerDeploymentType != "" {
+		if !containsString(globalVars.AppSpecServerDeploymentTypes[:], ServerDeploymentType) {
+			return fmt.Errorf(errorHandling.InvalidServerDeploymentTypeErr, globalVars.AppSpecServerDeploymentTypes)
+		}
+		deployment.deploymentType = ServerDeploymentType
+	}
+
+	// Blue/green deployments always reroute traffic with a load balancer
+	deployment.withLoadBalancer = deployment.withLoadBalancer || ServerWithLoadBalancer || deployment.deploymentType == "blue-green"
+	serverDeployment = &deployment
+
+	return nil
+}
+
+func loadServerDeploymentGroupFile(deploymentGroupFilePath string) (serverDeploymentConfig, error) {
+	deploymentGroupBytes, err := ioutil.ReadFile(deploymentGroupFilePath)
+	if err != nil {
+		return serverDeploymentConfig{}, err
+	}
+
+	var deploymentGroup models.DeploymentGroupModel
+	if err := json.Unmarshal(deploymentGroupBytes, &deploymentGroup); err != nil {
+		return serverDeploymentConfig{}, fmt.Errorf(errorHandling.InvalidDeploymentGroupFileErr, deploymentGroupFilePath, err)
+	}
+
+	deploymentStyle := deploymentGroup.DeploymentGroupInfo.DeploymentStyle
+	deployment := serverDeploymentConfig{deploymentType: "in-place", withLoadBalancer: deploymentStyle.DeploymentOption == "WITH_TRAFFIC_CONTROL"}
+
+	switch deploymentStyle.DeploymentType {
+	case "BLUE_GREEN":
+		deployment.deploymentType = "blue-green"
+	case "IN_PLACE", "":
+	default:
+		return deployment, fmt.Errorf(errorHandling.InvalidDeploymentGroupFileErr, deploymentGroupFilePath, deploymentStyle.DeploymentType)
+	}
+
+	return deployment, nil
+}
+
+// Where each hook runs in the deployment
+func getServerDeploymentHookTargets(deployment serverDeploymentConfig) []serverHookTarget {
+	if deployment.deploymentType == "blue-green" {
+		return []serverHookTarget{
+			{"Original instances", globalVars.AppSpecServerBlueGreenOriginalHooks[:]},
+			{"Replacement instances", globalVars.AppSpecServerBlueGreenReplacementHooks[:]},
+		}
+	}
+
+	if !deployment.withLoadBalancer {
+		return []serverHookTarget{{"Instances", globalVars.AppSpecSupportedServerHooksWithoutLB[:]}}
+	}
+
+	var hooks []string
+	for _, lifecycleEvent := range globalVars.AppSpecServerLifecycleEvents {
+		if !containsString(globalVars.AppSpecServerReservedLifecycleEvents[:], lifecycleEvent) {
+			hooks = append(hooks, lifecycleEvent)
+		}
+	}
+
+	return []serverHookTarget{{"Instances", hooks}}
+}
+
+func getServerDeploymentDescription(deployment serverDeploymentConfig) string {
+	description := "a blue-green deployment"
+	if deployment.deploymentType == "in-place" {
+		description = "an in-place deployment"
+	}
+
+	if deployment.withLoadBalancer {
+		return description + " with a load balancer"
+	}
+
+	return description + " without a load balancer"
+}
+
+// Show which hooks of the AppSpec run on which instances, and warn about the ones that never run
+// Returns the hooks that never run
+func validateServerDeploymentHooks(serverHooks map[string][]models.Hook, deployment serverDeploymentConfig) []string {
+	deploymentDescription := getServerDeploymentDescription(deployment)
+	runningHooks := map[string]bool{}
+
+	fmt.Printf(errorHandling.ServerDeploymentHooksHeaderMsg, deploymentDescription)
+	for _, target := range getServerDeploymentHookTargets(deployment) {
+		var targetHooks []string
+		for _, hook := range target.hooks {
+			if _, ok := serverHooks[hook]; ok {
+				targetHooks = append(targetHooks, hook)
+				runningHooks[hook] = true
+			}
+		}
+		fmt.Printf(errorHandling.ServerDeploymentHookTargetMsg, target.instances, targetHooks)
+	}
+
+	var neverRunningHooks []string
+	for _, hook := range getSortedHookKeys(serverHooks) {
+		if !runningHooks[hook] && isSupportedServerHook(hook) {
+			fmt.Printf(errorHandling.NeverRunningServerHookWarn, hook, deploymentDescription)
+			neverRunningHooks = append(neverRunningHooks, hook)
+		}
+	}
+
+	return neverRunningHooks
+}
+
+func isSupportedServerHook(hook string) bool {
+	return containsString(globalVars.AppSpecSupportedServerHooksWithoutLB[:], hook) || containsString(globalVars.AppSpecSupportedServerHooksWithLB[:], hook)
+}
@@ -0,0 +1,217 @@
+package assistant
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+func resetTestServerDeployment() {
+	ServerDeploymentType = ""
+	ServerWithLoadBalancer = false
+	ServerDeploymentGroupFilePath = ""
+	serverDeployment = nil
+}
+
+func writeTestDeploymentGroupFile(t *testing.T, content string) (string, func()) {
+	dirPath, err := ioutil.TempDir("", "appSpecAssistant-deploymentGroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filePath := filepath.Join(dirPath, "deployment-group.json")
+	if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return filePath, func() { os.RemoveAll(dirPath) }
+}
+
+// Test getServerDeploymentHookTargets
+func TestGetServerDeploymentHookTargets(t *testing.T) {
+	var tests = []struct {
+		name       string
+		deployment serverDeploymentConfig
+		expected   []serverHookTarget
+	}{
+		{"In-place without a load balancer",
+			serverDeploymentConfig{"in-place", false},
+			[]serverHookTarget{{"Instances", []string{"ApplicationStop", "BeforeInstall", "AfterInstall", "ApplicationStart", "ValidateService"}}},
+		},
+		{"In-place with a load balancer",
+			serverDeploymentConfig{"in-place", true},
+			[]serverHookTarget{{"Instances", []string{"BeforeBlockTraffic", "AfterBlockTraffic", "ApplicationStop", "BeforeInstall", "AfterInstall",
+				"ApplicationStart", "ValidateService", "BeforeAllowTraffic", "AfterAllowTraffic"}}},
+		},
+		{"Blue/green",
+			serverDeploymentConfig{"blue-green", true},
+			[]serverHookTarget{
+				{"Original instances", []string{"BeforeBlockTraffic", "AfterBlockTraffic"}},
+				{"Replacement instances", []string{"ApplicationStop", "BeforeInstall", "AfterInstall", "ApplicationStart", "ValidateService",
+					"BeforeAllowTraffic", "AfterAllowTraffic"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		output := getServerDeploymentHookTargets(test.deployment)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The getServerDeploymentHookTargets function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+// Test validateServerDeploymentHooks
+func TestValidateServerDeploymentHooks(t *testing.T) {
+	hookScript := []models.Hook{{Location: "scripts/hook.sh"}}
+
+	var tests = []struct {
+		name       string
+		hooks      []string
+		deployment serverDeploymentConfig
+		expected   []string
+	}{
+		{"In-place without a load balancer, traffic hooks never run",
+			[]string{"ApplicationStart", "BeforeBlockTraffic", "AfterAllowTraffic"},
+			serverDeploymentConfig{"in-place", false},
+			[]string{"AfterAllowTraffic", "BeforeBlockTraffic"},
+		},
+		{"In-place with a load balancer, every hook runs",
+			[]string{"ApplicationStart", "BeforeBlockTraffic", "AfterAllowTraffic"},
+			serverDeploymentConfig{"in-place", true},
+			nil,
+		},
+		{"Blue/green, every hook runs",
+			[]string{"BeforeBlockTraffic", "BeforeInstall", "AfterAllowTraffic"},
+			serverDeploymentConfig{"blue-green", true},
+			nil,
+		},
+		{"Unsupported hooks are left to validateServerHooks",
+			[]string{"NotHook", "ValidateService"},
+			serverDeploymentConfig{"in-place", false},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		serverHooks := map[string][]models.Hook{}
+		for _, hook := range test.hooks {
+			serverHooks[hook] = hookScript
+		}
+
+		output := validateServerDeploymentHooks(serverHooks, test.deployment)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The validateServerDeploymentHooks function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+// Test loadServerDeploymentGroupFile
+func TestLoadServerDeploymentGroupFile(t *testing.T) {
+	var tests = []struct {
+		name     string
+		content  string
+		expected serverDeploymentConfig
+	}{
+		{"Blue/green with traffic control",
+			`{"deploymentGroupInfo": {"deploymentStyle": {"deploymentType": "BLUE_GREEN", "deploymentOption": "WITH_TRAFFIC_CONTROL"}}}`,
+			serverDeploymentConfig{"blue-green", true},
+		},
+		{"In-place with traffic control",
+			`{"deploymentGroupInfo": {"deploymentStyle": {"deploymentType": "IN_PLACE", "deploymentOption": "WITH_TRAFFIC_CONTROL"}}}`,
+			serverDeploymentConfig{"in-place", true},
+		},
+		{"In-place without traffic control",
+			`{"deploymentGroupInfo": {"deploymentStyle": {"deploymentType": "IN_PLACE", "deploymentOption": "WITHOUT_TRAFFIC_CONTROL"}}}`,
+			serverDeploymentConfig{"in-place", false},
+		},
+		{"No deployment style",
+			`{"deploymentGroupInfo": {"deploymentGroupName": "group"}}`,
+			serverDeploymentConfig{"in-place", false},
+		},
+	}
+
+	for _, test := range tests {
+		filePath, cleanup := writeTestDeploymentGroupFile(t, test.content)
+		output, err := loadServerDeploymentGroupFile(filePath)
+		cleanup()
+
+		if err != nil {
+			t.Errorf("The loadServerDeploymentGroupFile function failed with %v but should have succeeded for: %v", err, test.name)
+		} else if output != test.expected {
+			t.Errorf("The loadServerDeploymentGroupFile function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+func TestLoadServerDeploymentGroupFile_InvalidInput(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+	}{
+		{"Not JSON", "deploymentType: BLUE_GREEN"},
+		{"Unknown deployment type", `{"deploymentGroupInfo": {"deploymentStyle": {"deploymentType": "CANARY"}}}`},
+	}
+
+	for _, test := range tests {
+		filePath, cleanup := writeTestDeploymentGroupFile(t, test.content)
+		_, err := loadServerDeploymentGroupFile(filePath)
+		cleanup()
+
+		if err == nil {
+			t.Errorf("The loadServerDeploymentGroupFile function succeeded but should have failed for: %v", test.name)
+		}
+	}
+}
+
+// Test setupServerDeployment
+func TestSetupServerDeployment(t *testing.T) {
+	filePath, cleanup := writeTestDeploymentGroupFile(t,
+		`{"deploymentGroupInfo": {"deploymentStyle": {"deploymentType": "IN_PLACE", "deploymentOption": "WITHOUT_TRAFFIC_CONTROL"}}}`)
+	defer cleanup()
+	defer resetTestServerDeployment()
+
+	var tests = []struct {
+		name             string
+		deploymentType   string
+		withLoadBalancer bool
+		filePath         string
+		expected         *serverDeploymentConfig
+	}{
+		{"Nothing set", "", false, "", nil},
+		{"Load balancer only", "", true, "", &serverDeploymentConfig{"in-place", true}},
+		{"Blue/green implies a load balancer", "blue-green", false, "", &serverDeploymentConfig{"blue-green", true}},
+		{"File only", "", false, filePath, &serverDeploymentConfig{"in-place", false}},
+		{"Options override the file", "blue-green", false, filePath, &serverDeploymentConfig{"blue-green", true}},
+	}
+
+	for _, test := range tests {
+		ServerDeploymentType, ServerWithLoadBalancer, ServerDeploymentGroupFilePath = test.deploymentType, test.withLoadBalancer, test.filePath
+
+		if err := setupServerDeployment(); err != nil {
+			t.Errorf("The setupServerDeployment function failed with %v but should have succeeded for: %v", err, test.name)
+		} else if !reflect.DeepEqual(serverDeployment, test.expected) {
+			t.Errorf("The setupServerDeployment function set %v but should have set %v for: %v", serverDeployment, test.expected, test.name)
+		}
+	}
+
+	ServerDeploymentType, ServerWithLoadBalancer, ServerDeploymentGroupFilePath = "rolling", false, ""
+	if err := setupServerDeployment(); err == nil {
+		t.Errorf("The setupServerDeployment function succeeded but should have failed for an unknown deployment type")
+	}
+}
+
+// Test validateServerHooks with the reserved lifecycle events
+func TestValidateServerHooks_ReservedHooks(t *testing.T) {
+	for _, hook := range []string{"Install", "DownloadBundle", "BlockTraffic", "AllowTraffic"} {
+		hooks := map[string][]models.Hook{hook: {{Location: "scripts/hook.sh"}}}
+
+		if validateServerHooks(hooks) {
+			t.Errorf("The validateServerHooks function succeeded but should have failed for the reserved lifecycle event: %v", hook)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package assistant
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// A file of the revision and where the files section puts it on the instance
+type serverFileCopy struct {
+	source      string // Relative to the AppSpec root, slash separated
+	destination string // Path on the instance
+	fileIndex   int    // Index of the files entry that copies it
+}
+
+// C:\ style destinations of Windows Server instances
+var windowsDrivePattern = regexp.MustCompile(`^[A-Za-z]:`)
+
+// Resolve the files section against the files of a revision (relative to the AppSpec root) the way the CodeDeploy agent copies them
+// A file source is copied into the destination directory, a directory source copies its content,
+// and a source of / copies the whole revision
+func resolveServerFiles(files []models.File, revisionFiles []string) ([]serverFileCopy, error) {
+	var fileCopies []serverFileCopy
+
+	for i, file := range files {
+		source := cleanRevisionPath(file.Source)
+		numOfCopies := len(fileCopies)
+
+		for _, revisionFile := range revisionFiles {
+			if revisionFile == source {
+				fileCopies = append(fileCopies, serverFileCopy{revisionFile, joinInstancePath(file.Destination, path.Base(revisionFile)), i})
+			} else if source == "" || strings.HasPrefix(revisionFile, source+"/") {
+				fileCopies = append(fileCopies, serverFileCopy{revisionFile, joinInstancePath(file.Destination, strings.TrimPrefix(revisionFile, source+"/")), i})
+			}
+		}
+
+		if len(fileCopies) == numOfCopies {
+			return nil, fmt.Errorf(errorHandling.MissingRevisionFileSourceErr, file.Source)
+		}
+	}
+
+	return fileCopies, nil
+}
+
+// Join a destination directory and a relative path the way the instance OS does
+func joinInstancePath(destination string, relativePath string) string {
+	if windowsDrivePattern.MatchString(destination) || strings.Contains(destination, "\\") {
+		return strings.TrimRight(destination, "\\/") + "\\" + strings.Replace(relativePath, "/", "\\", -1)
+	}
+
+	return path.Join(destination, relativePath)
+}
+
+// Path inside the sandbox that stands in for a path on the instance (C:\app -> <sandbox>/C/app)
+func getSandboxPath(sandboxDirPath string, instancePath string) string {
+	slashPath := strings.Replace(instancePath, "\\", "/", -1)
+	if windowsDrivePattern.MatchString(slashPath) {
+		slashPath = slashPath[:1] + slashPath[2:]
+	}
+
+	return filepath.Join(sandboxDirPath, filepath.FromSlash(path.Clean("/"+slashPath)))
+}
@@ -0,0 +1,68 @@
+package assistant
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Test resolveServerFiles
+func TestResolveServerFiles(t *testing.T) {
+	revisionFiles := []string{"appspec.yml", "app/index.html", "app/static/app.js", "config/app.conf", "scripts/start.sh"}
+
+	var tests = []struct {
+		name                 string
+		files                []models.File
+		expectedDestinations []string
+	}{
+		{"Single file", []models.File{{"config/app.conf", "/etc/app"}}, []string{"/etc/app/app.conf"}},
+		{"Directory", []models.File{{"app", "/var/www"}}, []string{"/var/www/index.html", "/var/www/static/app.js"}},
+		{"Directory with slashes", []models.File{{"/app/", "/var/www/"}}, []string{"/var/www/index.html", "/var/www/static/app.js"}},
+		{"Whole revision", []models.File{{"/", "/opt/app"}},
+			[]string{"/opt/app/appspec.yml", "/opt/app/app/index.html", "/opt/app/app/static/app.js", "/opt/app/config/app.conf", "/opt/app/scripts/start.sh"}},
+		{"Windows destination", []models.File{{"app/static", "C:\\inetpub\\wwwroot"}}, []string{"C:\\inetpub\\wwwroot\\app.js"}},
+		{"Directory with a common prefix is not matched", []models.File{{"app/static/app", "/var/www"}}, nil},
+	}
+
+	for _, test := range tests {
+		fileCopies, err := resolveServerFiles(test.files, revisionFiles)
+		if test.expectedDestinations == nil {
+			if err == nil {
+				t.Errorf("The resolveServerFiles function succeeded but should have failed for: %v", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("The resolveServerFiles function failed for: %v, %v", test.name, err)
+			continue
+		}
+
+		var destinations []string
+		for _, fileCopy := range fileCopies {
+			destinations = append(destinations, fileCopy.destination)
+		}
+		if strings.Join(destinations, ",") != strings.Join(test.expectedDestinations, ",") {
+			t.Errorf("The resolveServerFiles function returned %v instead of %v for: %v", destinations, test.expectedDestinations, test.name)
+		}
+	}
+}
+
+// Test getSandboxPath
+func TestGetSandboxPath(t *testing.T) {
+	var tests = []struct {
+		instancePath string
+		expectedPath string
+	}{
+		{"/var/www/index.html", "sandbox/var/www/index.html"},
+		{"C:\\inetpub\\wwwroot\\index.html", "sandbox/C/inetpub/wwwroot/index.html"},
+		{"/var/../../etc/passwd", "sandbox/etc/passwd"},
+	}
+
+	for _, test := range tests {
+		if sandboxPath := getSandboxPath("sandbox", test.instancePath); sandboxPath != filepath.FromSlash(test.expectedPath) {
+			t.Errorf("The getSandboxPath function returned %v instead of %v", sandboxPath, test.expectedPath)
+		}
+	}
+}
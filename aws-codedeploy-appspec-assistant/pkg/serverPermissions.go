@@ -0,0 +1,179 @@
+package assistant
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Formats of the permissions values, as chmod, setfacl, chcon, and chown take them
+var serverPermissionModePattern = regexp.MustCompile(`^[0-7]{3,4}$`)
+var serverPermissionAclPattern = regexp.MustCompile(`^(d:)?((u|g):[^:\s]*|[mo]:):([rwxX-]{1,4}|[0-7])$`)
+var serverPermissionAccountPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.-]{0,30}[A-Za-z0-9_.$-]?|[0-9]+)$`)
+var seLinuxIdentifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+var seLinuxRangePattern = regexp.MustCompile(`^s[0-9]+(:c[0-9]+(\.c[0-9]+)?(,c[0-9]+(\.c[0-9]+)?)*)?(-s[0-9]+(:c[0-9]+(\.c[0-9]+)?(,c[0-9]+(\.c[0-9]+)?)*)?)?$`)
+
+// Whether a permissions entry applies to a path on the instance
+// The object itself, or anything below it that matches the pattern, is not excepted, and is of one of the types
+func isServerPermissionApplied(permission models.Permission, instancePath string, isDirectory bool) bool {
+	if permission.Object == "" || !isServerPermissionType(permission, isDirectory) {
+		return false
+	}
+
+	object := path.Clean(strings.Replace(permission.Object, "\\", "/", -1))
+	objectPath := path.Clean(strings.Replace(instancePath, "\\", "/", -1))
+
+	// A pattern picks what is below a directory object, the directory itself is only included by the default pattern
+	if objectPath == object {
+		return !isDirectory || permission.Pattern == "" || permission.Pattern == "**"
+	}
+
+	if !strings.HasPrefix(objectPath, strings.TrimSuffix(object, "/")+"/") {
+		return false
+	}
+
+	name := path.Base(objectPath)
+	if permission.Pattern != "" && permission.Pattern != "**" {
+		if matched, _ := path.Match(permission.Pattern, name); !matched {
+			return false
+		}
+	}
+
+	for _, exceptPattern := range getServerPermissionExceptPatterns(permission) {
+		if matched, _ := path.Match(exceptPattern, name); matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Warn about the permissions whose object CodeDeploy never writes to
+// Returns the objects that are not at or below any files destination
+func validateServerPermissionObjects(permissions []models.Permission, files []models.File) []string {
+	var uncoveredObjects []string
+
+	for _, permission := range permissions {
+		if permission.Object == "" || isAppSpecPlaceholder(permission.Object) || isServerPermissionObjectCovered(permission.Object, files) {
+			continue
+		}
+		fmt.Printf(errorHandling.UncoveredServerPermissionObjectWarn, permission.Object)
+		uncoveredObjects = append(uncoveredObjects, permission.Object)
+	}
+
+	return uncoveredObjects
+}
+
+// Whether the object is a files destination, below one, or above one so it selects the files copied there
+func isServerPermissionObjectCovered(object string, files []models.File) bool {
+	objectPath := path.Clean(strings.Replace(object, "\\", "/", -1))
+
+	for _, file := range files {
+		if file.Destination == "" || isAppSpecPlaceholder(file.Destination) {
+			// Nothing to compare with for this entry
+			continue
+		}
+
+		destination := path.Clean(strings.Replace(file.Destination, "\\", "/", -1))
+		if objectPath == destination || isServerPathBelow(objectPath, destination) || isServerPathBelow(destination, objectPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Both paths are cleaned, the root is a parent of every other path
+func isServerPathBelow(childPath string, parentPath string) bool {
+	return strings.HasPrefix(childPath, strings.TrimSuffix(parentPath, "/")+"/")
+}
+
+// Without a type the permissions apply to files and directories
+func isServerPermissionType(permission models.Permission, isDirectory bool) bool {
+	if len(permission.Type) < 1 {
+		return true
+	}
+
+	for _, permissionType := range permission.Type {
+		if (permissionType == "directory") == isDirectory {
+			return true
+		}
+	}
+
+	return false
+}
+
+// except is a list of patterns: [*.tmp, *.log]
+func getServerPermissionExceptPatterns(permission models.Permission) []string {
+	var exceptPatterns []string
+
+	for _, exceptPattern := range strings.Split(strings.Trim(permission.Except, "[] "), ",") {
+		if exceptPattern = strings.Trim(exceptPattern, " \"'"); exceptPattern != "" {
+			exceptPatterns = append(exceptPatterns, exceptPattern)
+		}
+	}
+
+	return exceptPatterns
+}
+
+// Check the values of a permissions entry, placeholders are reported by the placeholder check
+// Returns the errors found
+func getServerPermissionErrs(permission models.Permission) []string {
+	var errs []string
+
+	if isServerPermissionValueSet(permission.Mode) && !serverPermissionModePattern.MatchString(permission.Mode) {
+		errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionModeErr, permission.Mode, permission.Object))
+	}
+
+	for _, acl := range permission.Acls {
+		if isServerPermissionValueSet(acl) && !serverPermissionAclPattern.MatchString(strings.TrimSpace(acl)) {
+			errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionAclErr, acl, permission.Object))
+		}
+	}
+
+	context := permission.Context
+	if isServerPermissionValueSet(context.User) && !seLinuxIdentifierPattern.MatchString(context.User) {
+		errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionContextErr, "user", "an SELinux user like system_u", context.User, permission.Object))
+	}
+	if isServerPermissionValueSet(context.Type) && !seLinuxIdentifierPattern.MatchString(context.Type) {
+		errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionContextErr, "type", "an SELinux type like httpd_sys_content_t", context.Type, permission.Object))
+	}
+	if isServerPermissionValueSet(context.Range) && !seLinuxRangePattern.MatchString(context.Range) {
+		errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionContextErr, "range", "an MLS/MCS range like s0 or s0-s0:c0.c1023", context.Range, permission.Object))
+	}
+	if context.Type == "" && (context.User != "" || context.Range != "") {
+		errs = append(errs, fmt.Sprintf(errorHandling.MissingServerPermissionContextTypeErr, permission.Object))
+	}
+
+	if isServerPermissionValueSet(permission.Pattern) {
+		if _, err := path.Match(permission.Pattern, ""); err != nil {
+			errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionGlobErr, "pattern", permission.Pattern, permission.Object))
+		}
+	}
+	// except lists look like the [...] template placeholders, so they are checked either way
+	if permission.Except != "" && permission.Pattern == "" {
+		errs = append(errs, fmt.Sprintf(errorHandling.ExceptWithoutPatternServerPermissionErr, permission.Object))
+	}
+	for _, exceptPattern := range getServerPermissionExceptPatterns(permission) {
+		if _, err := path.Match(exceptPattern, ""); err != nil && !isAppSpecPlaceholder(exceptPattern) {
+			errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionGlobErr, "except", exceptPattern, permission.Object))
+		}
+	}
+
+	if isServerPermissionValueSet(permission.Owner) && !serverPermissionAccountPattern.MatchString(permission.Owner) {
+		errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionAccountErr, "owner", permission.Owner, permission.Object))
+	}
+	if isServerPermissionValueSet(permission.Group) && !serverPermissionAccountPattern.MatchString(permission.Group) {
+		errs = append(errs, fmt.Sprintf(errorHandling.InvalidServerPermissionAccountErr, "group", permission.Group, permission.Object))
+	}
+
+	return errs
+}
+
+func isServerPermissionValueSet(value string) bool {
+	return value != "" && !isAppSpecPlaceholder(value)
+}
@@ -0,0 +1,183 @@
+package assistant
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Owner and group of the files the CodeDeploy agent copies, it runs as root
+const serverDefaultOwner = "root"
+
+// Mode of the directories the agent creates below the files destinations, and of files that have none in the revision
+const serverDefaultDirectoryMode = 0755
+const serverDefaultFileMode = 0644
+
+// What a deployed file or directory ends up with once the permissions are applied
+type serverEffectivePermission struct {
+	instancePath string
+	isDirectory  bool
+	owner        string
+	group        string
+	mode         uint32 // As chmod takes it, with the setuid, setgid, and sticky bits
+	acls         []string
+	rules        []int // Indexes of the permissions entries that apply, in order
+}
+
+// Main function of the permissions-report command
+func ReportServerPermissions(revisionPath string) {
+	fmt.Println("reportServerPermissions called on:", revisionPath)
+
+	revision, err := loadAppSpecRevision(revisionPath)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	appSpecBytes, err := revision.readAppSpec()
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	// The files sources must be in the revision to know what is deployed
+	serverRevision = revision
+	if err := runValidation(appSpecBytes, "server"); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	serverAppSpecModel, err := getServerAppSpecObjFromString(appSpecBytes)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	fileCopies, err := resolveServerFiles(serverAppSpecModel.Files, revision.getFiles())
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	effectivePermissions := getServerEffectivePermissions(serverAppSpecModel.Permissions, serverAppSpecModel.Files, fileCopies, getServerFileModes(revision, fileCopies))
+
+	fmt.Printf(errorHandling.ServerPermissionsReportHeaderMsg, len(effectivePermissions))
+	fmt.Printf("%-50s %-10s %-12s %-12s %-5s %-30s %s\n", "PATH", "TYPE", "OWNER", "GROUP", "MODE", "ACLS", "RULES")
+	for _, effectivePermission := range effectivePermissions {
+		fmt.Println(getServerEffectivePermissionLine(effectivePermission))
+	}
+
+	appliedRules := map[int]bool{}
+	for _, effectivePermission := range effectivePermissions {
+		for _, rule := range effectivePermission.rules {
+			appliedRules[rule] = true
+		}
+	}
+	for i, permission := range serverAppSpecModel.Permissions {
+		if !appliedRules[i] {
+			fmt.Printf(errorHandling.UnappliedServerPermissionWarn, i, permission.Object)
+		}
+	}
+}
+
+// Modes of the copied files as they are in the revision, by instance path
+func getServerFileModes(revision *appSpecRevision, fileCopies []serverFileCopy) map[string]uint32 {
+	fileModes := map[string]uint32{}
+
+	for _, fileCopy := range fileCopies {
+		if entry, ok := revision.getEntry(fileCopy.source); ok {
+			fileModes[fileCopy.destination] = uint32(entry.mode.Perm())
+		}
+	}
+
+	return fileModes
+}
+
+// Apply the permissions to every deployed file and directory in the order they are listed, later entries override earlier ones
+// acls entries are added like setfacl -m does, replacing the entry for the same user or group
+func getServerEffectivePermissions(permissions []models.Permission, files []models.File, fileCopies []serverFileCopy, fileModes map[string]uint32) []serverEffectivePermission {
+	var effectivePermissions []serverEffectivePermission
+	instancePaths, isDirectory := getServerInstalledPaths(files, fileCopies)
+
+	for _, instancePath := range instancePaths {
+		effectivePermission := serverEffectivePermission{
+			instancePath: instancePath,
+			isDirectory:  isDirectory[instancePath],
+			owner:        serverDefaultOwner,
+			group:        serverDefaultOwner,
+			mode:         serverDefaultFileMode,
+		}
+		if fileMode, ok := fileModes[instancePath]; ok {
+			effectivePermission.mode = fileMode
+		}
+		if effectivePermission.isDirectory {
+			effectivePermission.mode = serverDefaultDirectoryMode
+		}
+
+		for i, permission := range permissions {
+			if !isServerPermissionApplied(permission, instancePath, effectivePermission.isDirectory) {
+				continue
+			}
+			effectivePermission.rules = append(effectivePermission.rules, i)
+
+			if permission.Owner != "" {
+				effectivePermission.owner = permission.Owner
+			}
+			if permission.Group != "" {
+				effectivePermission.group = permission.Group
+			}
+			if mode, err := strconv.ParseUint(permission.Mode, 8, 32); err == nil {
+				effectivePermission.mode = uint32(mode)
+			}
+			for _, acl := range permission.Acls {
+				effectivePermission.acls = mergeServerPermissionAcl(effectivePermission.acls, strings.TrimSpace(acl))
+			}
+		}
+
+		effectivePermissions = append(effectivePermissions, effectivePermission)
+	}
+
+	return effectivePermissions
+}
+
+// Add an acls entry, replacing the one for the same (default) user, group, mask, or other
+func mergeServerPermissionAcl(acls []string, acl string) []string {
+	for i, existingAcl := range acls {
+		if getServerPermissionAclKey(existingAcl) == getServerPermissionAclKey(acl) {
+			acls[i] = acl
+			return acls
+		}
+	}
+
+	return append(acls, acl)
+}
+
+// The acls entry without its permissions: u:bob:rwx -> u:bob
+func getServerPermissionAclKey(acl string) string {
+	if i := strings.LastIndex(acl, ":"); i >= 0 {
+		return acl[:i]
+	}
+
+	return acl
+}
+
+func getServerEffectivePermissionLine(effectivePermission serverEffectivePermission) string {
+	pathType := "file"
+	if effectivePermission.isDirectory {
+		pathType = "directory"
+	}
+
+	acls := strings.Join(effectivePermission.acls, ",")
+	if acls == "" {
+		acls = "-"
+	}
+
+	var rules []string
+	for _, rule := range effectivePermission.rules {
+		rules = append(rules, fmt.Sprintf("permissions[%d]", rule))
+	}
+	if len(rules) < 1 {
+		rules = []string{"-"}
+	}
+
+	return fmt.Sprintf("%-50s %-10s %-12s %-12s %04o  %-30s %s", effectivePermission.instancePath, pathType,
+		effectivePermission.owner, effectivePermission.group, effectivePermission.mode, acls, strings.Join(rules, ", "))
+}
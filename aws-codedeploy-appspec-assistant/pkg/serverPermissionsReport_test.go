@@ -0,0 +1,113 @@
+package assistant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+var testReportServerFiles = []models.File{{Source: "app", Destination: "/var/www"}}
+
+var testReportServerFileCopies = []serverFileCopy{
+	{"app/index.html", "/var/www/index.html", 0},
+	{"app/bin/run.sh", "/var/www/bin/run.sh", 0},
+}
+
+var testReportServerFileModes = map[string]uint32{"/var/www/index.html": 0600, "/var/www/bin/run.sh": 0755}
+
+// Test getServerEffectivePermissions
+func TestGetServerEffectivePermissions(t *testing.T) {
+	var tests = []struct {
+		name        string
+		permissions []models.Permission
+		expected    []serverEffectivePermission
+	}{
+		{"No permissions, root:root and the modes of the revision",
+			nil,
+			[]serverEffectivePermission{
+				{"/var/www", true, "root", "root", 0755, nil, nil},
+				{"/var/www/bin", true, "root", "root", 0755, nil, nil},
+				{"/var/www/bin/run.sh", false, "root", "root", 0755, nil, nil},
+				{"/var/www/index.html", false, "root", "root", 0600, nil, nil},
+			},
+		},
+		{"Later entries override earlier ones",
+			[]models.Permission{
+				{Object: "/var/www", Owner: "apache", Group: "apache", Mode: "640", Acls: []string{"u:bob:r", "g:ops:r"}, Type: []string{"file"}},
+				{Object: "/var/www", Pattern: "*.sh", Mode: "4750", Acls: []string{"u:bob:rwx"}},
+				{Object: "/var/www", Owner: "deploy", Mode: "2775", Type: []string{"directory"}},
+			},
+			[]serverEffectivePermission{
+				{"/var/www", true, "deploy", "root", 02775, nil, []int{2}},
+				{"/var/www/bin", true, "deploy", "root", 02775, nil, []int{2}},
+				{"/var/www/bin/run.sh", false, "apache", "apache", 04750, []string{"u:bob:rwx", "g:ops:r"}, []int{0, 1}},
+				{"/var/www/index.html", false, "apache", "apache", 0640, []string{"u:bob:r", "g:ops:r"}, []int{0}},
+			},
+		},
+		{"Except and a single file object",
+			[]models.Permission{
+				{Object: "/var/www", Pattern: "**", Except: "[*.sh]", Group: "web"},
+				{Object: "/var/www/bin/run.sh", Mode: "700"},
+			},
+			[]serverEffectivePermission{
+				{"/var/www", true, "root", "web", 0755, nil, []int{0}},
+				{"/var/www/bin", true, "root", "web", 0755, nil, []int{0}},
+				{"/var/www/bin/run.sh", false, "root", "root", 0700, nil, []int{1}},
+				{"/var/www/index.html", false, "root", "web", 0600, nil, []int{0}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		output := getServerEffectivePermissions(test.permissions, testReportServerFiles, testReportServerFileCopies, testReportServerFileModes)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The getServerEffectivePermissions function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+// Test mergeServerPermissionAcl
+func TestMergeServerPermissionAcl(t *testing.T) {
+	var tests = []struct {
+		name     string
+		acls     []string
+		acl      string
+		expected []string
+	}{
+		{"New user", []string{"u:bob:r"}, "u:alice:rw", []string{"u:bob:r", "u:alice:rw"}},
+		{"Same user", []string{"u:bob:r", "g:ops:r"}, "u:bob:rwx", []string{"u:bob:rwx", "g:ops:r"}},
+		{"Default acl of the same user", []string{"u:bob:r"}, "d:u:bob:rwx", []string{"u:bob:r", "d:u:bob:rwx"}},
+		{"Mask", []string{"m::rwx"}, "m::r", []string{"m::r"}},
+	}
+
+	for _, test := range tests {
+		if output := mergeServerPermissionAcl(test.acls, test.acl); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The mergeServerPermissionAcl function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+// Test getServerEffectivePermissionLine
+func TestGetServerEffectivePermissionLine(t *testing.T) {
+	var tests = []struct {
+		name                string
+		effectivePermission serverEffectivePermission
+		expected            []string
+	}{
+		{"Directory without entries", serverEffectivePermission{"/var/www", true, "root", "root", 0755, nil, nil},
+			[]string{"/var/www", "directory", "root", "0755", " - ", " -"}},
+		{"File with entries", serverEffectivePermission{"/var/www/run.sh", false, "apache", "web", 04750, []string{"u:bob:rwx", "m::rx"}, []int{0, 2}},
+			[]string{"/var/www/run.sh", "file", "apache", "web", "4750", "u:bob:rwx,m::rx", "permissions[0], permissions[2]"}},
+	}
+
+	for _, test := range tests {
+		line := getServerEffectivePermissionLine(test.effectivePermission)
+		for _, expected := range test.expected {
+			if !strings.Contains(line, expected) {
+				t.Errorf("The getServerEffectivePermissionLine function returned %q without %q for: %v", line, expected, test.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package assistant
+
+import (
+	"reflect"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Test isServerPermissionApplied
+func TestIsServerPermissionApplied(t *testing.T) {
+	var tests = []struct {
+		name         string
+		permission   models.Permission
+		instancePath string
+		isDirectory  bool
+		expected     bool
+	}{
+		{"Object itself", models.Permission{Object: "/var/www"}, "/var/www", true, true},
+		{"Directory object with a pattern", models.Permission{Object: "/var/www", Pattern: "*.sh"}, "/var/www", true, false},
+		{"File object with a pattern", models.Permission{Object: "/var/www/run.sh", Pattern: "*.js"}, "/var/www/run.sh", false, true},
+		{"Directory object with the default pattern", models.Permission{Object: "/var/www", Pattern: "**"}, "/var/www", true, true},
+		{"Below object", models.Permission{Object: "/var/www/"}, "/var/www/static/app.js", false, true},
+		{"Outside object", models.Permission{Object: "/var/www"}, "/var/www2/index.html", false, false},
+		{"Pattern matches", models.Permission{Object: "/var/www", Pattern: "*.sh"}, "/var/www/bin/start.sh", false, true},
+		{"Pattern does not match", models.Permission{Object: "/var/www", Pattern: "*.sh"}, "/var/www/index.html", false, false},
+		{"Pattern of all files", models.Permission{Object: "/var/www", Pattern: "**"}, "/var/www/index.html", false, true},
+		{"Excepted", models.Permission{Object: "/var/www", Except: "[*.tmp, *.log]"}, "/var/www/app.log", false, false},
+		{"Not excepted", models.Permission{Object: "/var/www", Except: "[*.tmp, *.log]"}, "/var/www/app.js", false, true},
+		{"File type only", models.Permission{Object: "/var/www", Type: []string{"file"}}, "/var/www/static", true, false},
+		{"Directory type", models.Permission{Object: "/var/www", Type: []string{"directory"}}, "/var/www/static", true, true},
+		{"Empty object", models.Permission{}, "/var/www", true, false},
+	}
+
+	for _, test := range tests {
+		if applied := isServerPermissionApplied(test.permission, test.instancePath, test.isDirectory); applied != test.expected {
+			t.Errorf("The isServerPermissionApplied function returned %v instead of %v for: %v", applied, test.expected, test.name)
+		}
+	}
+}
+
+// Test getServerPermissionErrs
+func TestGetServerPermissionErrs_ValidInput(t *testing.T) {
+	var tests = []struct {
+		name       string
+		permission models.Permission
+	}{
+		{"Object only", models.Permission{Object: "/var/www"}},
+		{"3 digit mode", models.Permission{Object: "/var/www", Mode: "644"}},
+		{"4 digit mode", models.Permission{Object: "/var/www", Mode: "4755"}},
+		{"Acls", models.Permission{Object: "/var/www", Acls: []string{"u:ec2-user:rwx", "g:web:rw", "d:u::rwx", "m::r", "o::-", "u:1001:7"}}},
+		{"Context", models.Permission{Object: "/var/www", Context: models.Context{User: "system_u", Type: "httpd_sys_content_t", Range: "s0-s0:c0.c1023"}}},
+		{"Context type only", models.Permission{Object: "/var/www", Context: models.Context{Type: "httpd_sys_content_t"}}},
+		{"Context range with categories", models.Permission{Object: "/var/www", Context: models.Context{Type: "var_t", Range: "s0:c1,c3.c5"}}},
+		{"Pattern and except", models.Permission{Object: "/var/www", Pattern: "*.sh", Except: "[test-*.sh, [a-c]*.sh]"}},
+		{"Pattern of all files", models.Permission{Object: "/var/www", Pattern: "**"}},
+		{"Owner and group", models.Permission{Object: "/var/www", Owner: "ec2-user", Group: "www-data"}},
+		{"Numeric owner and machine account group", models.Permission{Object: "/var/www", Owner: "1001", Group: "host$"}},
+		{"Placeholders", models.Permission{Object: "object-specification", Mode: "mode-specification", Acls: []string{"acls-specification"},
+			Owner: "owner-account-name", Group: "group-name", Pattern: "pattern-specification", Except: "exception-specification",
+			Context: models.Context{User: "user-specification", Type: "type-specification", Range: "range-specification"}}},
+	}
+
+	for _, test := range tests {
+		if errs := getServerPermissionErrs(test.permission); len(errs) > 0 {
+			t.Errorf("The getServerPermissionErrs function returned %v but should have returned none for: %v", errs, test.name)
+		}
+	}
+}
+
+func TestGetServerPermissionErrs_InvalidInput(t *testing.T) {
+	var tests = []struct {
+		name       string
+		permission models.Permission
+		numOfErrs  int
+	}{
+		{"Mode not octal", models.Permission{Object: "/var/www", Mode: "855"}, 1},
+		{"Mode too short", models.Permission{Object: "/var/www", Mode: "75"}, 1},
+		{"Mode too long", models.Permission{Object: "/var/www", Mode: "07555"}, 1},
+		{"Symbolic mode", models.Permission{Object: "/var/www", Mode: "u+x"}, 1},
+		{"Acl without qualifier", models.Permission{Object: "/var/www", Acls: []string{"u:rwx"}}, 1},
+		{"Acl of unknown type", models.Permission{Object: "/var/www", Acls: []string{"x:bob:rw", "u:bob:rw"}}, 1},
+		{"Acl with bad permissions", models.Permission{Object: "/var/www", Acls: []string{"u:bob:read"}}, 1},
+		{"Mask acl with qualifier", models.Permission{Object: "/var/www", Acls: []string{"m:bob:r"}}, 1},
+		{"Context user", models.Permission{Object: "/var/www", Context: models.Context{User: "system u", Type: "var_t"}}, 1},
+		{"Context type", models.Permission{Object: "/var/www", Context: models.Context{Type: "system_u:object_r:var_t"}}, 1},
+		{"Context range", models.Permission{Object: "/var/www", Context: models.Context{Type: "var_t", Range: "c0.c1023"}}, 1},
+		{"Context without type", models.Permission{Object: "/var/www", Context: models.Context{User: "system_u", Range: "s0"}}, 1},
+		{"Bad pattern", models.Permission{Object: "/var/www", Pattern: "*.[ch"}, 1},
+		{"Bad except", models.Permission{Object: "/var/www", Pattern: "*", Except: "[*.tmp, *.[log]"}, 1},
+		{"Except without pattern", models.Permission{Object: "/var/www", Except: "[*.tmp]"}, 1},
+		{"Owner with space", models.Permission{Object: "/var/www", Owner: "web user"}, 1},
+		{"Group starting with a dash", models.Permission{Object: "/var/www", Group: "-web"}, 1},
+		{"Owner too long", models.Permission{Object: "/var/www", Owner: "a-very-long-user-name-over-32-chars"}, 1},
+		{"Several errors", models.Permission{Object: "/var/www", Mode: "999", Owner: "web user", Group: "web group"}, 3},
+	}
+
+	for _, test := range tests {
+		if errs := getServerPermissionErrs(test.permission); len(errs) != test.numOfErrs {
+			t.Errorf("The getServerPermissionErrs function returned %v but should have returned %d errors for: %v", errs, test.numOfErrs, test.name)
+		}
+	}
+}
+
+// Test validateServerPermissionObjects
+func TestValidateServerPermissionObjects(t *testing.T) {
+	files := []models.File{{Source: "app", Destination: "/var/www/html/"}, {Source: "config/app.conf", Destination: "/etc/app"}}
+
+	var tests = []struct {
+		name        string
+		permissions []models.Permission
+		expected    []string
+	}{
+		{"Destination itself", []models.Permission{{Object: "/var/www/html"}}, nil},
+		{"Below a destination", []models.Permission{{Object: "/etc/app/app.conf"}, {Object: "/var/www/html/static/"}}, nil},
+		{"Parent of a destination", []models.Permission{{Object: "/var/www"}, {Object: "/"}}, nil},
+		{"Sibling with the same prefix", []models.Permission{{Object: "/etc/app2"}}, []string{"/etc/app2"}},
+		{"Never written", []models.Permission{{Object: "/opt/app"}, {Object: "/etc/app"}, {Object: "/var/log/app"}}, []string{"/opt/app", "/var/log/app"}},
+		{"Placeholder object", []models.Permission{{Object: "object-specification"}}, nil},
+	}
+
+	for _, test := range tests {
+		if output := validateServerPermissionObjects(test.permissions, files); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The validateServerPermissionObjects function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+// Test isServerPermissionObjectCovered
+func TestIsServerPermissionObjectCovered(t *testing.T) {
+	var tests = []struct {
+		name     string
+		object   string
+		files    []models.File
+		expected bool
+	}{
+		{"Root destination", "/opt/app", []models.File{{Source: "/", Destination: "/"}}, true},
+		{"Windows style separators", "C:\\app\\bin", []models.File{{Source: "/", Destination: "C:\\app"}}, true},
+		{"Parent of a destination", "/var/www", []models.File{{Source: "app", Destination: "/var/www/html"}}, true},
+		{"Sibling of a destination", "/var/www/htmlx", []models.File{{Source: "app", Destination: "/var/www/html"}}, false},
+		{"Placeholder destination", "/opt/app", []models.File{{Source: "source-file-location", Destination: "destination-file-location"}}, false},
+		{"Placeholder and empty destinations before a covering one", "/opt/app", []models.File{{Source: "source-file-location", Destination: "destination-file-location"}, {Source: "app"}, {Source: "app", Destination: "/opt"}}, true},
+		{"Placeholder destination next to one that does not cover", "/opt/app", []models.File{{Source: "source-file-location", Destination: "destination-file-location"}, {Source: "app", Destination: "/var/www"}}, false},
+		{"No files", "/opt/app", nil, false},
+	}
+
+	for _, test := range tests {
+		if output := isServerPermissionObjectCovered(test.object, test.files); output != test.expected {
+			t.Errorf("The isServerPermissionObjectCovered function returned %v instead of %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package assistant
+
+import (
+	"fmt"
+	"time"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// A lifecycle event of the timeline, with one of its scripts
+// Reserved events have no script and are run by CodeDeploy
+type serverTimelineStep struct {
+	lifecycleEvent   string
+	location         string
+	runas            string
+	timeout          int
+	isDefaultTimeout bool
+}
+
+// The lifecycle events on the instances of a deployment, and how long their scripts can take at most
+type serverTimeline struct {
+	instances         string
+	steps             []serverTimelineStep
+	worstCaseDuration int
+}
+
+// Main function of the timeline command
+func ShowServerTimeline(filePath string) {
+	fmt.Println("showServerTimeline called on:", filePath)
+
+	appSpecBytes, err := loadAppSpecFile(filePath, "server")
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := setupServerDeployment(); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := runValidation(appSpecBytes, "server"); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	serverAppSpecModel, err := getServerAppSpecObjFromString(appSpecBytes)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	// Deployment groups are in-place without a load balancer unless set otherwise
+	deployment := serverDeploymentConfig{deploymentType: "in-place"}
+	if serverDeployment != nil {
+		deployment = *serverDeployment
+	}
+
+	fmt.Printf(errorHandling.ServerTimelineHeaderMsg, getServerDeploymentDescription(deployment))
+	for _, timeline := range getServerTimelines(serverAppSpecModel.Hooks, deployment) {
+		fmt.Printf(errorHandling.ServerTimelineInstancesMsg, timeline.instances)
+		for _, line := range getServerTimelineLines(timeline) {
+			fmt.Println(line)
+		}
+		fmt.Printf(errorHandling.ServerTimelineWorstCaseMsg, timeline.worstCaseDuration, time.Duration(timeline.worstCaseDuration)*time.Second)
+	}
+
+	fmt.Println()
+	if len(serverAppSpecModel.Hooks["ApplicationStop"]) > 0 {
+		fmt.Println(errorHandling.SimulateServerApplicationStopWarn)
+	}
+	for _, lifecycleEvent := range globalVars.AppSpecServerLifecycleEvents {
+		for _, hookScript := range serverAppSpecModel.Hooks[lifecycleEvent] {
+			if hookScript.Timeout == "" {
+				fmt.Printf(errorHandling.DefaultServerScriptTimeoutWarn, hookScript.Location, lifecycleEvent, globalVars.ServerDefaultScriptTimeout)
+			}
+		}
+	}
+}
+
+// The lifecycle events of each group of instances of the deployment in order, with the scripts of the AppSpec
+// The worst case adds up the timeouts of the scripts, the events run by CodeDeploy are not counted
+func getServerTimelines(serverHooks map[string][]models.Hook, deployment serverDeploymentConfig) []serverTimeline {
+	var timelines []serverTimeline
+
+	for _, target := range getServerDeploymentHookTargets(deployment) {
+		timeline := serverTimeline{instances: target.instances}
+
+		for _, lifecycleEvent := range globalVars.AppSpecServerLifecycleEvents {
+			if containsString(globalVars.AppSpecServerReservedLifecycleEvents[:], lifecycleEvent) {
+				if isServerReservedEventInTarget(lifecycleEvent, target) {
+					timeline.steps = append(timeline.steps, serverTimelineStep{lifecycleEvent: lifecycleEvent})
+				}
+				continue
+			}
+			if !containsString(target.hooks, lifecycleEvent) {
+				continue
+			}
+
+			for _, hookScript := range serverHooks[lifecycleEvent] {
+				timeout := getServerScriptTimeout(hookScript)
+				timeline.steps = append(timeline.steps, serverTimelineStep{lifecycleEvent, hookScript.Location, hookScript.Runas, timeout, hookScript.Timeout == ""})
+				timeline.worstCaseDuration += timeout
+			}
+		}
+
+		timelines = append(timelines, timeline)
+	}
+
+	return timelines
+}
+
+// Start and End run on every instance, the other reserved events only with the hooks around them
+func isServerReservedEventInTarget(lifecycleEvent string, target serverHookTarget) bool {
+	switch lifecycleEvent {
+	case "DownloadBundle", "Install":
+		return containsString(target.hooks, "BeforeInstall")
+	case "BlockTraffic":
+		return containsString(target.hooks, "BeforeBlockTraffic")
+	case "AllowTraffic":
+		return containsString(target.hooks, "BeforeAllowTraffic")
+	}
+
+	return true
+}
+
+// One line per step, as a table
+func getServerTimelineLines(timeline serverTimeline) []string {
+	lines := []string{fmt.Sprintf("  %-20s %-40s %-12s %s", "EVENT", "SCRIPT", "RUNAS", "TIMEOUT")}
+
+	for _, step := range timeline.steps {
+		if step.location == "" {
+			lines = append(lines, fmt.Sprintf("  %-20s %s", step.lifecycleEvent, "(run by CodeDeploy)"))
+			continue
+		}
+
+		runas := step.runas
+		if runas == "" {
+			runas = "(agent user)"
+		}
+		timeout := fmt.Sprintf("%ds", step.timeout)
+		if step.isDefaultTimeout {
+			timeout += " (default)"
+		}
+
+		// The agent runs the ApplicationStop scripts of the previous successful revision, the timeline shows the ones of this AppSpec
+		if step.lifecycleEvent == "ApplicationStop" {
+			timeout += " (previous revision)"
+		}
+
+		lines = append(lines, fmt.Sprintf("  %-20s %-40s %-12s %s", step.lifecycleEvent, step.location, runas, timeout))
+	}
+
+	return lines
+}
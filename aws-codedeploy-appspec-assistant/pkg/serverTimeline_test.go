@@ -0,0 +1,109 @@
+package assistant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+var testTimelineServerHooks = map[string][]models.Hook{
+	"ApplicationStop": {{Location: "scripts/stop.sh", Timeout: "300"}},
+	"BeforeInstall": {
+		{Location: "scripts/deps.sh", Timeout: "900", Runas: "root"},
+		{Location: "scripts/clean.sh"},
+	},
+	"BeforeBlockTraffic": {{Location: "scripts/drain.sh", Timeout: "60"}},
+}
+
+// Test getServerTimelines
+func TestGetServerTimelines(t *testing.T) {
+	var tests = []struct {
+		name       string
+		deployment serverDeploymentConfig
+		expected   []serverTimeline
+	}{
+		{"In-place without a load balancer, traffic hooks do not run",
+			serverDeploymentConfig{"in-place", false},
+			[]serverTimeline{{"Instances", []serverTimelineStep{
+				{"ApplicationStop", "scripts/stop.sh", "", 300, false},
+				{lifecycleEvent: "DownloadBundle"},
+				{"BeforeInstall", "scripts/deps.sh", "root", 900, false},
+				{"BeforeInstall", "scripts/clean.sh", "", 3600, true},
+				{lifecycleEvent: "Install"},
+			}, 4800}},
+		},
+		{"In-place with a load balancer",
+			serverDeploymentConfig{"in-place", true},
+			[]serverTimeline{{"Instances", []serverTimelineStep{
+				{"BeforeBlockTraffic", "scripts/drain.sh", "", 60, false},
+				{lifecycleEvent: "BlockTraffic"},
+				{"ApplicationStop", "scripts/stop.sh", "", 300, false},
+				{lifecycleEvent: "DownloadBundle"},
+				{"BeforeInstall", "scripts/deps.sh", "root", 900, false},
+				{"BeforeInstall", "scripts/clean.sh", "", 3600, true},
+				{lifecycleEvent: "Install"},
+				{lifecycleEvent: "AllowTraffic"},
+			}, 4860}},
+		},
+		{"Blue/green, original and replacement instances",
+			serverDeploymentConfig{"blue-green", true},
+			[]serverTimeline{
+				{"Original instances", []serverTimelineStep{
+					{"BeforeBlockTraffic", "scripts/drain.sh", "", 60, false},
+					{lifecycleEvent: "BlockTraffic"},
+				}, 60},
+				{"Replacement instances", []serverTimelineStep{
+					{"ApplicationStop", "scripts/stop.sh", "", 300, false},
+					{lifecycleEvent: "DownloadBundle"},
+					{"BeforeInstall", "scripts/deps.sh", "root", 900, false},
+					{"BeforeInstall", "scripts/clean.sh", "", 3600, true},
+					{lifecycleEvent: "Install"},
+					{lifecycleEvent: "AllowTraffic"},
+				}, 4800},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		output := getServerTimelines(testTimelineServerHooks, test.deployment)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The getServerTimelines function returned %v but should have returned %v for: %v", output, test.expected, test.name)
+		}
+	}
+}
+
+// Test getServerTimelineLines
+func TestGetServerTimelineLines(t *testing.T) {
+	timeline := serverTimeline{"Instances", []serverTimelineStep{
+		{"ApplicationStop", "scripts/stop.sh", "", 300, false},
+		{lifecycleEvent: "DownloadBundle"},
+		{"BeforeInstall", "scripts/deps.sh", "root", 900, false},
+		{"BeforeInstall", "scripts/clean.sh", "", 3600, true},
+	}, 4800}
+
+	var tests = []struct {
+		name     string
+		line     int
+		expected []string
+	}{
+		{"ApplicationStop script of the previous revision", 1, []string{"ApplicationStop", "scripts/stop.sh", "300s (previous revision)"}},
+		{"Reserved event", 2, []string{"DownloadBundle", "(run by CodeDeploy)"}},
+		{"Script with runas and timeout", 3, []string{"BeforeInstall", "scripts/deps.sh", "root", "900s"}},
+		{"Script with the default timeout", 4, []string{"scripts/clean.sh", "(agent user)", "3600s (default)"}},
+	}
+
+	lines := getServerTimelineLines(timeline)
+	if len(lines) != 5 {
+		t.Fatalf("The getServerTimelineLines function returned %d lines but should have returned 5: %v", len(lines), lines)
+	}
+
+	for _, test := range tests {
+		for _, expected := range test.expected {
+			if !strings.Contains(lines[test.line], expected) {
+				t.Errorf("The getServerTimelineLines function returned %q without %q for: %v", lines[test.line], expected, test.name)
+			}
+		}
+	}
+}
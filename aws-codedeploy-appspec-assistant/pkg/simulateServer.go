@@ -0,0 +1,385 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Statuses of the hook scripts of a simulated deployment, next to the hook statuses of the mock
+const hookStatusTimedOut = "TimedOut"
+
+// Environment variables the CodeDeploy agent sets for hook scripts
+type serverDeploymentEnv struct {
+	deploymentId        string
+	applicationName     string
+	deploymentGroupName string
+}
+
+type serverHookScriptResult struct {
+	lifecycleEvent string
+	location       string
+	status         string
+}
+
+// Main function of the simulate command
+// Only the files are put in the sandbox, the scripts run unconfined on this machine so runScriptsOnHost has to confirm it
+func SimulateServerDeployment(revisionPath string, sandboxDirPath string, withLoadBalancer bool, applicationName string, deploymentGroupName string, runScriptsOnHost bool) {
+	fmt.Println("simulateServerDeployment called on:", revisionPath)
+
+	revision, err := loadAppSpecRevision(revisionPath)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	// Scripts are run from the revision, so archives have to be unpacked first
+	if !revision.isDir {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.UnpackedSimulateServerRevisionErr, revisionPath))
+	}
+
+	appSpecBytes, err := revision.readAppSpec()
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if err := runValidation(appSpecBytes, "server"); err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	serverAppSpecModel, err := getServerAppSpecObjFromString(appSpecBytes)
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if (serverAppSpecModel.OS == "windows") != (runtime.GOOS == "windows") {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.UnsupportedSimulateServerOSErr, serverAppSpecModel.OS, serverAppSpecModel.OS))
+	}
+
+	if !runScriptsOnHost && hasServerHookScripts(serverAppSpecModel.Hooks) {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.UnconfirmedSimulateServerScriptsErr))
+	}
+
+	fileCopies, err := resolveServerFiles(serverAppSpecModel.Files, revision.getFiles())
+	if err != nil {
+		errorHandling.HandleError(err)
+	}
+
+	if sandboxDirPath == "" {
+		if sandboxDirPath, err = ioutil.TempDir("", "appSpecAssistant-sandbox"); err != nil {
+			errorHandling.HandleError(err)
+		}
+	}
+	fmt.Printf(errorHandling.SimulateServerSandboxMsg, sandboxDirPath)
+
+	if applicationName == "" {
+		applicationName = mockCodeDeployApplicationName
+	}
+	if deploymentGroupName == "" {
+		deploymentGroupName = mockCodeDeployDeploymentGroupName
+	}
+	deploymentEnv := serverDeploymentEnv{getSampleDeploymentId(appSpecBytes), applicationName, deploymentGroupName}
+
+	results, deploymentSucceeded := runServerLifecycleEvents(serverAppSpecModel, revision, sandboxDirPath, fileCopies, withLoadBalancer, deploymentEnv)
+
+	fmt.Printf(errorHandling.SimulateServerResultsHeaderMsg, deploymentEnv.deploymentId)
+	for _, result := range results {
+		fmt.Printf("%-20s %-10s %s\n", result.lifecycleEvent, result.status, result.location)
+	}
+
+	if !deploymentSucceeded {
+		errorHandling.HandleError(fmt.Errorf(errorHandling.SimulateServerFailedErr, deploymentEnv.deploymentId))
+	}
+
+	fmt.Printf(errorHandling.SimulateServerSucceededMsg, deploymentEnv.deploymentId)
+}
+
+// Run the lifecycle events of an in-place deployment in order
+// Files are copied into the sandbox at Install, and like the agent the deployment stops at the first script that fails
+func runServerLifecycleEvents(serverAppSpecModel models.ServerAppSpecModel, revision *appSpecRevision, sandboxDirPath string,
+	fileCopies []serverFileCopy, withLoadBalancer bool, deploymentEnv serverDeploymentEnv) ([]serverHookScriptResult, bool) {
+	var results []serverHookScriptResult
+	deploymentFailed := false
+
+	for _, lifecycleEvent := range globalVars.AppSpecServerLifecycleEvents {
+		hookScripts := serverAppSpecModel.Hooks[lifecycleEvent]
+		isReserved := containsString(globalVars.AppSpecServerReservedLifecycleEvents[:], lifecycleEvent)
+
+		if !isReserved && len(hookScripts) < 1 {
+			continue
+		}
+
+		if deploymentFailed {
+			for _, hookScript := range hookScripts {
+				results = append(results, serverHookScriptResult{lifecycleEvent, hookScript.Location, hookStatusSkipped})
+			}
+			continue
+		}
+
+		if isServerTrafficLifecycleEvent(lifecycleEvent) && !withLoadBalancer {
+			if len(hookScripts) > 0 {
+				fmt.Printf(errorHandling.SimulateServerLifecycleEventMsg, lifecycleEvent)
+				fmt.Println(errorHandling.SimulateServerNoLoadBalancerMsg)
+			}
+			for _, hookScript := range hookScripts {
+				results = append(results, serverHookScriptResult{lifecycleEvent, hookScript.Location, hookStatusSkipped})
+			}
+			continue
+		}
+
+		fmt.Printf(errorHandling.SimulateServerLifecycleEventMsg, lifecycleEvent)
+
+		if lifecycleEvent == "Install" {
+			numOfModes, err := installServerFiles(serverAppSpecModel, revision, sandboxDirPath, fileCopies)
+			if err != nil {
+				fmt.Println(err)
+				deploymentFailed = true
+				continue
+			}
+			fmt.Printf(errorHandling.SimulateServerInstallMsg, len(fileCopies), numOfModes)
+			continue
+		}
+
+		if isReserved {
+			fmt.Println(errorHandling.SimulateServerReservedEventMsg)
+			continue
+		}
+
+		if lifecycleEvent == "ApplicationStop" {
+			fmt.Println(errorHandling.SimulateServerApplicationStopWarn)
+		}
+
+		for _, hookScript := range hookScripts {
+			if deploymentFailed {
+				results = append(results, serverHookScriptResult{lifecycleEvent, hookScript.Location, hookStatusSkipped})
+				continue
+			}
+
+			status := runServerHookScript(hookScript, lifecycleEvent, revision, sandboxDirPath, deploymentEnv)
+			results = append(results, serverHookScriptResult{lifecycleEvent, hookScript.Location, status})
+			deploymentFailed = status != hookStatusSucceeded
+		}
+	}
+
+	return results, !deploymentFailed
+}
+
+// Run a hook script from the revision with the environment of the agent, killing it after its timeout
+// SANDBOX_ROOT tells the script where the files are, nothing stops it from writing anywhere else
+func runServerHookScript(hookScript models.Hook, lifecycleEvent string, revision *appSpecRevision, sandboxDirPath string, deploymentEnv serverDeploymentEnv) string {
+	timeout := getServerScriptTimeout(hookScript)
+	fmt.Printf(errorHandling.SimulateServerRunningScriptMsg, hookScript.Location, timeout)
+
+	if hookScript.Runas != "" {
+		fmt.Printf(errorHandling.SimulateServerRunasWarn, hookScript.Runas, hookScript.Location)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	appSpecRootPath := filepath.Join(revision.path, filepath.FromSlash(revision.rootDir))
+	scriptPath := filepath.Join(appSpecRootPath, filepath.FromSlash(cleanRevisionPath(hookScript.Location)))
+	scriptCommand := exec.CommandContext(ctx, scriptPath)
+	if strings.HasSuffix(strings.ToLower(scriptPath), ".ps1") {
+		scriptCommand = exec.CommandContext(ctx, "powershell.exe", "-ExecutionPolicy", "Bypass", "-File", scriptPath)
+	} else if interpreter, err := getServerHookScriptInterpreter(scriptPath); err != nil {
+		fmt.Printf(errorHandling.SimulateServerScriptFailedErr, lifecycleEvent, hookScript.Location, err)
+		return hookStatusFailed
+	} else if len(interpreter) > 0 {
+		fmt.Printf(errorHandling.SimulateServerNotExecutableScriptWarn, hookScript.Location, strings.Join(interpreter, " "))
+		scriptCommand = exec.CommandContext(ctx, interpreter[0], append(interpreter[1:], scriptPath)...)
+	}
+
+	scriptCommand.Dir = appSpecRootPath
+	scriptCommand.Env = append(os.Environ(),
+		"LIFECYCLE_EVENT="+lifecycleEvent,
+		"DEPLOYMENT_ID="+deploymentEnv.deploymentId,
+		"APPLICATION_NAME="+deploymentEnv.applicationName,
+		"DEPLOYMENT_GROUP_NAME="+deploymentEnv.deploymentGroupName,
+		"SANDBOX_ROOT="+sandboxDirPath,
+	)
+	scriptCommand.Stdout = os.Stdout
+	scriptCommand.Stderr = os.Stderr
+
+	err := scriptCommand.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		fmt.Printf(errorHandling.SimulateServerScriptTimeoutErr, lifecycleEvent, hookScript.Location, timeout)
+		return hookStatusTimedOut
+	}
+	if err != nil {
+		fmt.Printf(errorHandling.SimulateServerScriptFailedErr, lifecycleEvent, hookScript.Location, err)
+		return hookStatusFailed
+	}
+
+	return hookStatusSucceeded
+}
+
+// The agent runs chmod +x on the scripts before running them, so a script without its executable bit still runs
+// Instead of changing the revision, it is run with the interpreter of its shebang line, like the kernel would
+// Returns nothing for scripts that can be run as they are
+func getServerHookScriptInterpreter(scriptPath string) ([]string, error) {
+	if runtime.GOOS == "windows" {
+		return nil, nil
+	}
+
+	info, err := os.Stat(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode().Perm()&0111 == 0111 {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	firstLine := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+	if !strings.HasPrefix(firstLine, "#!") {
+		return []string{"/bin/sh"}, nil
+	}
+
+	// Everything after the interpreter is one argument
+	interpreter := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(firstLine, "#!")), " ", 2)
+	if len(interpreter) == 2 {
+		interpreter[1] = strings.TrimSpace(interpreter[1])
+	}
+
+	return interpreter, nil
+}
+
+func hasServerHookScripts(hooks map[string][]models.Hook) bool {
+	for _, hookScripts := range hooks {
+		if len(hookScripts) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validation already checked the timeout is a number
+func getServerScriptTimeout(hookScript models.Hook) int {
+	timeout, err := strconv.Atoi(hookScript.Timeout)
+	if err != nil || timeout < 1 {
+		return globalVars.ServerDefaultScriptTimeout
+	}
+
+	return timeout
+}
+
+// The traffic lifecycle events only run in deployments with a load balancer
+func isServerTrafficLifecycleEvent(lifecycleEvent string) bool {
+	return lifecycleEvent == "BlockTraffic" || lifecycleEvent == "AllowTraffic" ||
+		containsString(globalVars.AppSpecSupportedServerHooksWithLB[:], lifecycleEvent)
+}
+
+// Copy the files into the sandbox and apply the modes of the permissions
+// Returns the number of modes applied
+func installServerFiles(serverAppSpecModel models.ServerAppSpecModel, revision *appSpecRevision, sandboxDirPath string, fileCopies []serverFileCopy) (int, error) {
+	for _, fileCopy := range fileCopies {
+		if err := revision.copyFile(fileCopy.source, sandboxDirPath, getSandboxPath(sandboxDirPath, fileCopy.destination)); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(serverAppSpecModel.Permissions) < 1 {
+		return 0, nil
+	}
+
+	if serverAppSpecModel.OS == "windows" {
+		fmt.Println(errorHandling.SimulateServerWindowsPermissionWarn)
+		return 0, nil
+	}
+
+	return applyServerPermissionModes(serverAppSpecModel.Permissions, serverAppSpecModel.Files, sandboxDirPath, fileCopies)
+}
+
+// Apply the mode of each permission to the copied files and directories it matches, in order so later permissions win
+func applyServerPermissionModes(permissions []models.Permission, files []models.File, sandboxDirPath string, fileCopies []serverFileCopy) (int, error) {
+	numOfModes := 0
+	instancePaths, isDirectory := getServerInstalledPaths(files, fileCopies)
+
+	for _, permission := range permissions {
+		if permission.Owner != "" || permission.Group != "" || len(permission.Acls) > 0 || permission.Context != (models.Context{}) {
+			fmt.Printf(errorHandling.SimulateServerPermissionNotSetWarn, permission.Object)
+		}
+
+		if permission.Mode == "" {
+			continue
+		}
+
+		mode, err := strconv.ParseUint(permission.Mode, 8, 32)
+		if err != nil || mode > 07777 {
+			fmt.Printf(errorHandling.SimulateServerInvalidModeWarn, permission.Mode, permission.Object)
+			continue
+		}
+
+		for _, instancePath := range instancePaths {
+			if !isServerPermissionApplied(permission, instancePath, isDirectory[instancePath]) {
+				continue
+			}
+			if err := os.Chmod(getSandboxPath(sandboxDirPath, instancePath), getServerPermissionFileMode(uint32(mode))); err != nil {
+				return numOfModes, err
+			}
+			numOfModes++
+		}
+	}
+
+	return numOfModes, nil
+}
+
+// os.FileMode keeps the setuid, setgid, and sticky bits apart from the permission bits, unlike the chmod mode
+func getServerPermissionFileMode(mode uint32) os.FileMode {
+	fileMode := os.FileMode(mode & 0777)
+
+	if mode&04000 != 0 {
+		fileMode |= os.ModeSetuid
+	}
+	if mode&02000 != 0 {
+		fileMode |= os.ModeSetgid
+	}
+	if mode&01000 != 0 {
+		fileMode |= os.ModeSticky
+	}
+
+	return fileMode
+}
+
+// The copied files and the directories below the files destinations, sorted
+func getServerInstalledPaths(files []models.File, fileCopies []serverFileCopy) ([]string, map[string]bool) {
+	isDirectory := map[string]bool{}
+
+	for _, fileCopy := range fileCopies {
+		isDirectory[fileCopy.destination] = false
+
+		destinationDir := path.Clean(files[fileCopy.fileIndex].Destination)
+		for dir := path.Dir(fileCopy.destination); strings.HasPrefix(dir, destinationDir); dir = path.Dir(dir) {
+			isDirectory[dir] = true
+			if dir == destinationDir {
+				break
+			}
+		}
+	}
+
+	var instancePaths []string
+	for instancePath := range isDirectory {
+		instancePaths = append(instancePaths, instancePath)
+	}
+	sort.Strings(instancePaths)
+
+	return instancePaths, isDirectory
+}
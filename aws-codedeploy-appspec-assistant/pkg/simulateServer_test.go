@@ -0,0 +1,268 @@
+package assistant
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+var testSimulateServerYamlString = `version: 0.0
+os: linux
+files:
+  - source: app
+    destination: /var/www
+permissions:
+  - object: /var/www
+    pattern: "*.sh"
+    mode: 750
+hooks:
+  BeforeInstall:
+    - location: scripts/before_install.sh
+  AfterInstall:
+    - location: scripts/after_install.sh
+      timeout: 1
+  ApplicationStart:
+    - location: scripts/start.sh
+  AfterAllowTraffic:
+    - location: scripts/start.sh`
+
+// Write a revision with the test AppSpec and the scripts
+func writeTestServerRevision(t *testing.T, scripts map[string]string) (*appSpecRevision, models.ServerAppSpecModel, []serverFileCopy) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test scripts are shell scripts")
+	}
+
+	revisionDirPath, err := ioutil.TempDir("", "revision")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revisionFiles := map[string]string{"appspec.yml": testSimulateServerYamlString, "app/index.html": "<html></html>", "app/bin/run.sh": "#!/bin/sh\n"}
+	for location, script := range scripts {
+		revisionFiles[location] = "#!/bin/sh\n" + script + "\n"
+	}
+	for revisionFile, content := range revisionFiles {
+		os.MkdirAll(filepath.Dir(filepath.Join(revisionDirPath, revisionFile)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(revisionDirPath, revisionFile), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fileExtension = "yml"
+	serverAppSpecModel, err := getServerAppSpecObjFromString([]byte(testSimulateServerYamlString))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revision, err := loadAppSpecRevision(revisionDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileCopies, err := resolveServerFiles(serverAppSpecModel.Files, revision.getFiles())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return revision, serverAppSpecModel, fileCopies
+}
+
+func getTestServerHookScriptStatuses(results []serverHookScriptResult) string {
+	var statuses []string
+	for _, result := range results {
+		statuses = append(statuses, result.lifecycleEvent+":"+result.status)
+	}
+
+	return strings.Join(statuses, ",")
+}
+
+// Test runServerLifecycleEvents runs the scripts in lifecycle order with the agent environment
+func TestRunServerLifecycleEvents(t *testing.T) {
+	revision, serverAppSpecModel, fileCopies := writeTestServerRevision(t, map[string]string{
+		"scripts/before_install.sh": `echo "$LIFECYCLE_EVENT $DEPLOYMENT_ID $APPLICATION_NAME $DEPLOYMENT_GROUP_NAME" >> "$(dirname "$0")/../events.log"`,
+		"scripts/after_install.sh":  `echo "$LIFECYCLE_EVENT" >> events.log && test -f "$SANDBOX_ROOT/var/www/index.html"`,
+		"scripts/start.sh":          `echo "$LIFECYCLE_EVENT" >> events.log`,
+	})
+	defer os.RemoveAll(revision.path)
+
+	sandboxDirPath, _ := ioutil.TempDir("", "sandbox")
+	defer os.RemoveAll(sandboxDirPath)
+
+	deploymentEnv := serverDeploymentEnv{"d-TEST12345", "my-app", "my-group"}
+	results, succeeded := runServerLifecycleEvents(serverAppSpecModel, revision, sandboxDirPath, fileCopies, false, deploymentEnv)
+	if !succeeded {
+		t.Fatalf("The runServerLifecycleEvents function failed: %v", results)
+	}
+
+	expectedStatuses := "BeforeInstall:Succeeded,AfterInstall:Succeeded,ApplicationStart:Succeeded,AfterAllowTraffic:Skipped"
+	if statuses := getTestServerHookScriptStatuses(results); statuses != expectedStatuses {
+		t.Errorf("The runServerLifecycleEvents function returned %v instead of %v", statuses, expectedStatuses)
+	}
+
+	eventsLog, _ := ioutil.ReadFile(filepath.Join(revision.path, "events.log"))
+	if string(eventsLog) != "BeforeInstall d-TEST12345 my-app my-group\nAfterInstall\nApplicationStart\n" {
+		t.Errorf("The scripts did not run in lifecycle order with the agent environment: %q", eventsLog)
+	}
+
+	if _, err := os.Stat(filepath.Join(sandboxDirPath, "var", "www", "index.html")); err != nil {
+		t.Errorf("The files were not copied into the sandbox: %v", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(sandboxDirPath, "var", "www", "bin", "run.sh")); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("The permissions mode was not applied: %v, %v", info, err)
+	}
+	if info, err := os.Stat(filepath.Join(sandboxDirPath, "var", "www", "index.html")); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("The permissions mode was applied to a file that does not match the pattern: %v, %v", info, err)
+	}
+
+	// The traffic hooks only run with a load balancer
+	results, _ = runServerLifecycleEvents(serverAppSpecModel, revision, sandboxDirPath, fileCopies, true, deploymentEnv)
+	if statuses := getTestServerHookScriptStatuses(results); !strings.HasSuffix(statuses, "AfterAllowTraffic:Succeeded") {
+		t.Errorf("The runServerLifecycleEvents function did not run the traffic hook with a load balancer: %v", statuses)
+	}
+}
+
+// Test the deployment stops at the first script that fails or times out
+func TestRunServerLifecycleEvents_Failures(t *testing.T) {
+	var tests = []struct {
+		name             string
+		afterInstall     string
+		expectedStatuses string
+	}{
+		{"Script fails", "exit 3", "BeforeInstall:Succeeded,AfterInstall:Failed,ApplicationStart:Skipped,AfterAllowTraffic:Skipped"},
+		{"Script times out", "sleep 3", "BeforeInstall:Succeeded,AfterInstall:TimedOut,ApplicationStart:Skipped,AfterAllowTraffic:Skipped"},
+	}
+
+	for _, test := range tests {
+		revision, serverAppSpecModel, fileCopies := writeTestServerRevision(t, map[string]string{
+			"scripts/before_install.sh": "true",
+			"scripts/after_install.sh":  test.afterInstall,
+			"scripts/start.sh":          "true",
+		})
+		sandboxDirPath, _ := ioutil.TempDir("", "sandbox")
+
+		results, succeeded := runServerLifecycleEvents(serverAppSpecModel, revision, sandboxDirPath, fileCopies, false, serverDeploymentEnv{})
+		if succeeded {
+			t.Errorf("The runServerLifecycleEvents function succeeded but should have failed for: %v", test.name)
+		}
+		if statuses := getTestServerHookScriptStatuses(results); statuses != test.expectedStatuses {
+			t.Errorf("The runServerLifecycleEvents function returned %v instead of %v for: %v", statuses, test.expectedStatuses, test.name)
+		}
+
+		os.RemoveAll(revision.path)
+		os.RemoveAll(sandboxDirPath)
+	}
+}
+
+// Test a script without its executable bit runs, like the agent makes it executable first
+func TestRunServerLifecycleEvents_NotExecutableScript(t *testing.T) {
+	revision, serverAppSpecModel, fileCopies := writeTestServerRevision(t, map[string]string{
+		"scripts/before_install.sh": "true",
+		"scripts/after_install.sh":  "true",
+		"scripts/start.sh":          "true",
+	})
+	defer os.RemoveAll(revision.path)
+
+	scriptPath := filepath.Join(revision.path, "scripts", "after_install.sh")
+	os.Chmod(scriptPath, 0644)
+
+	sandboxDirPath, _ := ioutil.TempDir("", "sandbox")
+	defer os.RemoveAll(sandboxDirPath)
+
+	results, succeeded := runServerLifecycleEvents(serverAppSpecModel, revision, sandboxDirPath, fileCopies, false, serverDeploymentEnv{})
+	if !succeeded {
+		t.Errorf("The runServerLifecycleEvents function failed for a script without its executable bit: %v", getTestServerHookScriptStatuses(results))
+	}
+
+	if info, err := os.Stat(scriptPath); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("The runServerLifecycleEvents function changed the mode of the script in the revision: %v %v", info.Mode(), err)
+	}
+}
+
+// Test getServerHookScriptInterpreter
+func TestGetServerHookScriptInterpreter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Scripts are not run by their mode on Windows")
+	}
+
+	scriptDirPath, _ := ioutil.TempDir("", "scripts")
+	defer os.RemoveAll(scriptDirPath)
+
+	var tests = []struct {
+		name     string
+		content  string
+		mode     os.FileMode
+		expected []string
+	}{
+		{"Executable", "#!/bin/bash\n", 0755, nil},
+		{"Shebang", "#!/bin/bash\necho\n", 0644, []string{"/bin/bash"}},
+		{"Shebang with an argument", "#! /usr/bin/env python3 -u\n", 0644, []string{"/usr/bin/env", "python3 -u"}},
+		{"No shebang", "echo\n", 0644, []string{"/bin/sh"}},
+	}
+
+	for i, test := range tests {
+		scriptPath := filepath.Join(scriptDirPath, fmt.Sprintf("script%d.sh", i))
+		ioutil.WriteFile(scriptPath, []byte(test.content), test.mode)
+		os.Chmod(scriptPath, test.mode)
+
+		if output, err := getServerHookScriptInterpreter(scriptPath); err != nil || !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("The getServerHookScriptInterpreter function returned %v, %v but should have returned %v for: %v", output, err, test.expected, test.name)
+		}
+	}
+}
+
+// Test hasServerHookScripts
+func TestHasServerHookScripts(t *testing.T) {
+	var tests = []struct {
+		name     string
+		hooks    map[string][]models.Hook
+		expected bool
+	}{
+		{"No hooks", nil, false},
+		{"Hook without scripts", map[string][]models.Hook{"BeforeInstall": {}}, false},
+		{"Hook with a script", map[string][]models.Hook{"BeforeInstall": {}, "AfterInstall": {{Location: "scripts/after_install.sh"}}}, true},
+	}
+
+	for _, test := range tests {
+		if output := hasServerHookScripts(test.hooks); output != test.expected {
+			t.Errorf("The hasServerHookScripts function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test getServerPermissionFileMode
+func TestGetServerPermissionFileMode(t *testing.T) {
+	var tests = []struct {
+		mode     uint32
+		expected os.FileMode
+	}{
+		{0644, 0644},
+		{04750, os.ModeSetuid | 0750},
+		{02775, os.ModeSetgid | 0775},
+		{01777, os.ModeSticky | 0777},
+		{07755, os.ModeSetuid | os.ModeSetgid | os.ModeSticky | 0755},
+	}
+
+	for _, test := range tests {
+		if output := getServerPermissionFileMode(test.mode); output != test.expected {
+			t.Errorf("The getServerPermissionFileMode function returned %v but should have returned %v for: %04o", output, test.expected, test.mode)
+		}
+	}
+}
+
+// Test getServerScriptTimeout
+func TestGetServerScriptTimeout(t *testing.T) {
+	if timeout := getServerScriptTimeout(models.Hook{Location: "start.sh", Timeout: "300"}); timeout != 300 {
+		t.Errorf("The getServerScriptTimeout function returned %v instead of 300", timeout)
+	}
+	if timeout := getServerScriptTimeout(models.Hook{Location: "start.sh"}); timeout != 3600 {
+		t.Errorf("The getServerScriptTimeout function returned %v instead of the default 3600", timeout)
+	}
+}
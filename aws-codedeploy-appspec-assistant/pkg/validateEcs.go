@@ -11,6 +11,13 @@ import (
 	"aws-codedeploy-appspec-assistant/models"
 )
 
+// Launch types of an ECS service, unknown when neither the capacity providers nor the task definition tell
+const (
+	fargateLaunchType = "FARGATE"
+	ec2LaunchType     = "EC2"
+	unknownLaunchType = ""
+)
+
 // Convert ECS AppSpec string to ECS AppSpec Object
 // Deals with JSON adn YAML
 func getEcsAppSpecObjFromString(appSpecBytes []byte) (models.EcsAppSpecModel, error) {
@@ -92,6 +99,19 @@ func validateEcsResourceProperties(ecsProperties models.EcsProperties) bool {
 		propertiesValid = false
 		numOfErrors++
 		fmt.Println(errorHandling.EmptyECSTaskDefErr)
+	} else if EcsPipelineMode && ecsProperties.TaskDefinition != globalVars.EcsPipelineTaskDefinitionPlaceholder {
+		propertiesValid = false
+		numOfErrors++
+		fmt.Println(errorHandling.InvalidEcsPipelineTaskDefErr, ecsProperties.TaskDefinition)
+	} else if ecsProperties.TaskDefinition != globalVars.EcsPipelineTaskDefinitionPlaceholder &&
+		!isAppSpecPlaceholder(ecsProperties.TaskDefinition) && !isValidEcsTaskDefinition(ecsProperties.TaskDefinition) {
+		propertiesValid = false
+		numOfErrors++
+		fmt.Println(errorHandling.InvalidECSTaskDefFormatErr, ecsProperties.TaskDefinition)
+	}
+
+	if ecsTaskDefinition != nil && ecsProperties.TaskDefinition != "" && !validateEcsTaskDefinitionIdentifier(ecsProperties.TaskDefinition) {
+		propertiesValid = false
 	}
 
 	// LoadBalancerInfo
@@ -101,15 +121,29 @@ func validateEcsResourceProperties(ecsProperties models.EcsProperties) bool {
 	}
 
 	// PlatformVersion (Optional)
+	if ecsProperties.PlatformVersion != "" && !validateEcsPlatformVersion(ecsProperties.PlatformVersion, ecsProperties.CapacityProviderStrategy, ecsProperties.TaskDefinition) {
+		propertiesValid = false
+	}
+
+	if ecsTaskDefinition != nil && !validateEcsTaskDefinitionCompatibilities(ecsProperties.PlatformVersion, ecsProperties.TaskDefinition) {
+		propertiesValid = false
+	}
 
 	// NetworkConfiguration (Optional)
 	if isEcsNetworkConfigurationFilledOut(ecsProperties.NetworkConfiguration) {
-		if !validateEcsAwsvpcConfiguration(ecsProperties.NetworkConfiguration.AwsvpcConfiguration, ecsProperties.TaskDefinition) {
+		if !validateEcsAwsvpcConfiguration(ecsProperties.NetworkConfiguration.AwsvpcConfiguration, ecsProperties.TaskDefinition) ||
+			(ecsTaskDefinition != nil && !validateEcsTaskDefinitionNetworkMode(ecsProperties.TaskDefinition)) {
 			propertiesValid = false
 			fmt.Println(errorHandling.InvalidECSNetworkConfigurationErr)
 		}
 	}
 
+	// CapacityProviderStrategy (Optional)
+	if len(ecsProperties.CapacityProviderStrategy) > 0 && !validateEcsCapacityProviderStrategy(ecsProperties.CapacityProviderStrategy, ecsProperties.TaskDefinition) {
+		propertiesValid = false
+		fmt.Println(errorHandling.InvalidECSCapacityProviderStrategyErr)
+	}
+
 	return propertiesValid
 }
 
@@ -122,13 +156,121 @@ func validateEcsLoadBalancerInfo(ecsLoadBalancerInfo models.LoadBalancerInfo, ta
 		fmt.Println(errorHandling.MissingECSContainerNameErr, taskDefinition)
 	}
 
-	if ecsLoadBalancerInfo.ContainerPort == 0 {
-		fmt.Println(errorHandling.ZeroECSContainerPortWarn, taskDefinition)
+	if ecsLoadBalancerInfo.ContainerPort < 1 || ecsLoadBalancerInfo.ContainerPort > 65535 {
+		infoValid = false
+		numOfErrors++
+		fmt.Println(errorHandling.InvalidECSContainerPortErr, taskDefinition)
+	}
+
+	// Cross-check with the task definition (Optional)
+	if ecsTaskDefinition != nil && ecsLoadBalancerInfo.ContainerName != "" {
+		if !validateEcsTaskDefinitionContainer(ecsLoadBalancerInfo, taskDefinition) {
+			infoValid = false
+		}
 	}
 
 	return infoValid
 }
 
+// PlatformVersion must be a Fargate platform version and only applies to the Fargate launch type
+func validateEcsPlatformVersion(platformVersion string, capacityProviderStrategy []models.CapacityProviderStrategyItem, taskDefinition string) bool {
+	if isAppSpecPlaceholder(platformVersion) {
+		return true
+	}
+
+	if !validateEcsPlatformVersionValue(platformVersion) {
+		numOfErrors++
+		fmt.Printf(errorHandling.InvalidECSPlatformVersionErr, globalVars.AppSpecEcsPlatformVersions, platformVersion, taskDefinition)
+		return false
+	}
+
+	if getEcsLaunchType(capacityProviderStrategy) == ec2LaunchType {
+		fmt.Println(errorHandling.IgnoredECSPlatformVersionWarn, taskDefinition)
+	}
+
+	return true
+}
+
+func validateEcsPlatformVersionValue(platformVersion string) bool {
+	for _, supportedPlatformVersion := range globalVars.AppSpecEcsPlatformVersions {
+		if platformVersion == supportedPlatformVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The launch type is only known from the capacity providers or the task definition compatibilities
+func getEcsLaunchType(capacityProviderStrategy []models.CapacityProviderStrategyItem) string {
+	if len(capacityProviderStrategy) > 0 {
+		for _, capacityProviderStrategyItem := range capacityProviderStrategy {
+			for _, fargateCapacityProvider := range globalVars.AppSpecEcsFargateCapacityProviders {
+				if capacityProviderStrategyItem.CapacityProvider == fargateCapacityProvider {
+					return fargateLaunchType
+				}
+			}
+		}
+
+		// Every other capacity provider is an Auto Scaling group
+		for _, capacityProviderStrategyItem := range capacityProviderStrategy {
+			if capacityProviderStrategyItem.CapacityProvider == "" || isAppSpecPlaceholder(capacityProviderStrategyItem.CapacityProvider) {
+				return unknownLaunchType
+			}
+		}
+		return ec2LaunchType
+	}
+
+	if ecsTaskDefinition == nil || len(ecsTaskDefinition.RequiresCompatibilities) < 1 {
+		return unknownLaunchType
+	}
+
+	for _, compatibility := range ecsTaskDefinition.RequiresCompatibilities {
+		if compatibility == "FARGATE" {
+			return fargateLaunchType
+		}
+	}
+
+	return ec2LaunchType
+}
+
+func validateEcsCapacityProviderStrategy(capacityProviderStrategy []models.CapacityProviderStrategyItem, taskDefinition string) bool {
+	strategyValid := true
+	numProvidersWithBase := 0
+
+	for _, capacityProviderStrategyItem := range capacityProviderStrategy {
+		if capacityProviderStrategyItem.CapacityProvider == "" {
+			strategyValid = false
+			numOfErrors++
+			fmt.Println(errorHandling.MissingECSCapacityProviderErr, taskDefinition)
+		}
+
+		if capacityProviderStrategyItem.Base < 0 || capacityProviderStrategyItem.Base > globalVars.EcsMaxCapacityProviderBase {
+			strategyValid = false
+			numOfErrors++
+			fmt.Printf(errorHandling.InvalidECSCapacityProviderBaseErr, globalVars.EcsMaxCapacityProviderBase, capacityProviderStrategyItem.CapacityProvider, taskDefinition)
+		}
+
+		if capacityProviderStrategyItem.Weight < 0 || capacityProviderStrategyItem.Weight > globalVars.EcsMaxCapacityProviderWeight {
+			strategyValid = false
+			numOfErrors++
+			fmt.Printf(errorHandling.InvalidECSCapacityProviderWeightErr, globalVars.EcsMaxCapacityProviderWeight, capacityProviderStrategyItem.CapacityProvider, taskDefinition)
+		}
+
+		if capacityProviderStrategyItem.Base > 0 {
+			numProvidersWithBase++
+		}
+	}
+
+	if numProvidersWithBase > 1 {
+		strategyValid = false
+		numOfErrors++
+		fmt.Println(errorHandling.MultipleECSCapacityProviderBasesErr, taskDefinition)
+	}
+
+	return strategyValid
+}
+
 func isEcsNetworkConfigurationFilledOut(ecsNetworkConfig models.NetworkConfiguration) bool {
 	return isEcsAwsvpcConfigurationFilledOut(ecsNetworkConfig.AwsvpcConfiguration)
 }
@@ -156,8 +298,16 @@ func validateEcsAwsvpcConfiguration(ecsAwsvpcConfiguration models.AwsvpcConfigur
 				configValid = false
 				numOfErrors++
 				fmt.Println(errorHandling.EmptyECSSubnetStrsErr, taskDefinition)
+			} else if !isAppSpecPlaceholder(subnet) && !isValidEcsSubnetId(subnet) {
+				configValid = false
+				numOfErrors++
+				fmt.Printf(errorHandling.InvalidECSSubnetIdErr, subnet, taskDefinition)
 			}
 		}
+
+		if !validateEcsAwsvpcIdLimits("Subnets", ecsAwsvpcConfiguration.Subnets, globalVars.EcsMaxSubnets, taskDefinition) {
+			configValid = false
+		}
 	}
 
 	if ecsAwsvpcConfiguration.SecurityGroups == nil || len(ecsAwsvpcConfiguration.SecurityGroups) < 1 {
@@ -170,8 +320,16 @@ func validateEcsAwsvpcConfiguration(ecsAwsvpcConfiguration models.AwsvpcConfigur
 				configValid = false
 				numOfErrors++
 				fmt.Println(errorHandling.EmptyECSSecurityGroupStrsErr, taskDefinition)
+			} else if !isAppSpecPlaceholder(securityGroup) && !isValidEcsSecurityGroupId(securityGroup) {
+				configValid = false
+				numOfErrors++
+				fmt.Printf(errorHandling.InvalidECSSecurityGroupIdErr, securityGroup, taskDefinition)
 			}
 		}
+
+		if !validateEcsAwsvpcIdLimits("SecurityGroups", ecsAwsvpcConfiguration.SecurityGroups, globalVars.EcsMaxSecurityGroups, taskDefinition) {
+			configValid = false
+		}
 	}
 
 	if ecsAwsvpcConfiguration.AssignPublicIp == "" {
@@ -187,6 +345,25 @@ func validateEcsAwsvpcConfiguration(ecsAwsvpcConfiguration models.AwsvpcConfigur
 	return configValid
 }
 
+// Subnets and SecurityGroups have a maximum number of IDs and must not repeat an ID
+func validateEcsAwsvpcIdLimits(listName string, ids []string, maxIds int, taskDefinition string) bool {
+	idsValid := true
+
+	if len(ids) > maxIds {
+		idsValid = false
+		numOfErrors++
+		fmt.Printf(errorHandling.TooManyECSAwsvpcIdsErr, listName, maxIds, len(ids), taskDefinition)
+	}
+
+	if duplicateIds := getDuplicateValues(ids); len(duplicateIds) > 0 {
+		idsValid = false
+		numOfErrors++
+		fmt.Printf(errorHandling.DuplicateECSAwsvpcIdsErr, listName, duplicateIds, taskDefinition)
+	}
+
+	return idsValid
+}
+
 func validateEcsAssignPublicIpValue(assignPublicIpValue string) bool {
 	for _, supportedPublicIpValue := range globalVars.AppSpecEcsAssignPublicIpValues {
 		if assignPublicIpValue == supportedPublicIpValue {
@@ -210,12 +387,18 @@ func validateEcsHooks(ecsHooks []map[string]string) bool {
 					fmt.Println(errorHandling.EmptyEcsHookValErr, hook)
 					numOfErrors++
 					hooksValid = false
+				} else if !validateHookFunction(hook, val) {
+					hooksValid = false
 				}
 				numValidHooks++
 			}
 		}
 	}
 
+	if !validateUniqueHooks(ecsHooks) {
+		hooksValid = false
+	}
+
 	if numValidHooks != len(ecsHooks) {
 		numOfErrors++
 		fmt.Println(errorHandling.InvalidEcsHookStrErr, globalVars.AppSpecSupportedEcsHooks)
@@ -125,6 +125,7 @@ func TestValidateEcsResources_ValidInput(t *testing.T) {
 								"ENABLED",
 							},
 						},
+						nil,
 					},
 				},
 			}},
@@ -148,11 +149,41 @@ func TestValidateEcsResources_ValidInput(t *testing.T) {
 								},
 								[]string{
 									"ecs-security-group-1",
-									"ecs-security-group-2",
+									"[ecs-security-group-2]",
 								},
 								"DISABLED",
 							},
 						},
+						nil,
+					},
+				},
+			}},
+		},
+		{"One resource, real subnet and security group IDs",
+			[]models.Resource{{
+				models.TargetService{
+					"AWS::ECS::Service",
+					models.EcsProperties{
+						"arn:aws:ecs:us-east-1:111122223333:task-definition/web:3",
+						models.LoadBalancerInfo{
+							"web",
+							8000,
+						},
+						"1.4.0",
+						models.NetworkConfiguration{
+							models.AwsvpcConfiguration{
+								[]string{
+									"subnet-0a1b2c3d",
+									"subnet-0123456789abcdef0",
+								},
+								[]string{
+									"sg-0a1b2c3d",
+									"sg-0123456789abcdef0",
+								},
+								"DISABLED",
+							},
+						},
+						nil,
 					},
 				},
 			}},
@@ -192,11 +223,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -222,11 +254,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -252,11 +285,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -279,11 +313,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									[]string{},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -308,11 +343,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -340,6 +376,7 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -369,6 +406,7 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -394,11 +432,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"INVALID",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -424,11 +463,12 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -450,11 +490,41 @@ func TestValidateEcsResources_InvalidInput(t *testing.T) {
 									},
 									[]string{
 										"ecs-security-group-1",
-										"ecs-security-group-2",
+										"[ecs-security-group-2]",
+									},
+									"DISABLED",
+								},
+							},
+							nil,
+						},
+					},
+				},
+			},
+		},
+		{"One resource, subnet and security group names instead of IDs",
+			[]models.Resource{
+				{
+					models.TargetService{
+						"AWS::ECS::Service",
+						models.EcsProperties{
+							"arn:aws:ecs:us-east-1:111122223333:task-definition/web:3",
+							models.LoadBalancerInfo{
+								"web",
+								8000,
+							},
+							"1.4.0",
+							models.NetworkConfiguration{
+								models.AwsvpcConfiguration{
+									[]string{
+										"private-subnet-a",
+									},
+									[]string{
+										"web-sg",
 									},
 									"DISABLED",
 								},
 							},
+							nil,
 						},
 					},
 				},
@@ -485,7 +555,7 @@ func TestIsEcsNetworkConfigurationFilledOut_FilledOutInput(t *testing.T) {
 					},
 					[]string{
 						"ecs-security-group-1",
-						"ecs-security-group-2",
+						"[ecs-security-group-2]",
 					},
 					"DISABLED",
 				},
@@ -535,3 +605,184 @@ func TestIsEcsNetworkConfigurationFilledOut_NotFilledOutInput(t *testing.T) {
 		}
 	}
 }
+
+// Test validateEcsAwsvpcConfiguration
+func TestValidateEcsAwsvpcConfiguration(t *testing.T) {
+	tooManySubnets := make([]string, 17)
+	for i := range tooManySubnets {
+		tooManySubnets[i] = fmt.Sprintf("subnet-0a1b2c%02d", i)
+	}
+
+	var tests = []struct {
+		name           string
+		subnets        []string
+		securityGroups []string
+		expectedOutput bool
+	}{
+		{"Valid IDs", []string{"subnet-0a1b2c3d", "subnet-0123456789abcdef0"}, []string{"sg-0a1b2c3d"}, true},
+		{"Template placeholders", []string{"SubnetId1", "SubnetId2"}, []string{"ecs-security-group-1"}, true},
+		{"Subnet name instead of ID", []string{"private-subnet-a"}, []string{"sg-0a1b2c3d"}, false},
+		{"Security group name instead of ID", []string{"subnet-0a1b2c3d"}, []string{"web-sg"}, false},
+		{"More than 16 subnets", tooManySubnets, []string{"sg-0a1b2c3d"}, false},
+		{"More than 5 security groups", []string{"subnet-0a1b2c3d"}, []string{"sg-0a1b2c31", "sg-0a1b2c32", "sg-0a1b2c33", "sg-0a1b2c34", "sg-0a1b2c35", "sg-0a1b2c36"}, false},
+		{"Duplicate subnet", []string{"subnet-0a1b2c3d", "subnet-0a1b2c3d"}, []string{"sg-0a1b2c3d"}, false},
+		{"Duplicate security group", []string{"subnet-0a1b2c3d"}, []string{"sg-0a1b2c3d", "sg-0a1b2c3d"}, false},
+	}
+
+	for _, test := range tests {
+		awsvpcConfiguration := models.AwsvpcConfiguration{
+			Subnets:        test.subnets,
+			SecurityGroups: test.securityGroups,
+			AssignPublicIp: "DISABLED",
+		}
+		if output := validateEcsAwsvpcConfiguration(awsvpcConfiguration, "web:3"); output != test.expectedOutput {
+			t.Errorf("The validateEcsAwsvpcConfiguration function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test validateEcsLoadBalancerInfo
+func TestValidateEcsLoadBalancerInfo_ContainerPort(t *testing.T) {
+	var tests = []struct {
+		containerPort  int
+		expectedOutput bool
+	}{
+		{1, true},
+		{8080, true},
+		{65535, true},
+		{0, false},
+		{-80, false},
+		{65536, false},
+	}
+
+	for _, test := range tests {
+		loadBalancerInfo := models.LoadBalancerInfo{ContainerName: "web", ContainerPort: test.containerPort}
+		if output := validateEcsLoadBalancerInfo(loadBalancerInfo, "web:3"); output != test.expectedOutput {
+			t.Errorf("The validateEcsLoadBalancerInfo function returned %v for ContainerPort: %v", output, test.containerPort)
+		}
+	}
+}
+
+// Test validateEcsResourceProperties
+func TestValidateEcsResourceProperties_TaskDefinitionFormat(t *testing.T) {
+	var tests = []struct {
+		taskDefinition string
+		expectedOutput bool
+	}{
+		{"arn:aws:ecs:us-east-1:111122223333:task-definition/web:3", true},
+		{"web:3", true},
+		{"[Your task definition arn]", true},
+		{"web", false},
+		{"arn:aws:ecs:cn-north-1:111122223333:task-definition/web:3", false},
+	}
+
+	for _, test := range tests {
+		properties := models.EcsProperties{
+			TaskDefinition:   test.taskDefinition,
+			LoadBalancerInfo: models.LoadBalancerInfo{ContainerName: "web", ContainerPort: 8080},
+		}
+		if output := validateEcsResourceProperties(properties); output != test.expectedOutput {
+			t.Errorf("The validateEcsResourceProperties function returned %v for: %v", output, test.taskDefinition)
+		}
+	}
+}
+
+// Test validateEcsHooks
+func TestValidateEcsHooks_FunctionFormat(t *testing.T) {
+	var tests = []struct {
+		function       string
+		expectedOutput bool
+	}{
+		{"CodeDeployHook_BeforeInstall", true},
+		{"arn:aws:lambda:us-west-2:111122223333:function:CodeDeployHook_BeforeInstall", true},
+		{"my hook function", false},
+		{"arn:aws:lambda:us-west-2:111122223333:function", false},
+	}
+
+	for _, test := range tests {
+		if output := validateEcsHooks([]map[string]string{{"BeforeInstall": test.function}}); output != test.expectedOutput {
+			t.Errorf("The validateEcsHooks function returned %v for: %v", output, test.function)
+		}
+	}
+}
+
+// Test validateEcsCapacityProviderStrategy
+func TestValidateEcsCapacityProviderStrategy(t *testing.T) {
+	var tests = []struct {
+		name                     string
+		capacityProviderStrategy []models.CapacityProviderStrategyItem
+		expectedOutput           bool
+	}{
+		{"Fargate with Fargate Spot",
+			[]models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE", Base: 1, Weight: 1}, {CapacityProvider: "FARGATE_SPOT", Weight: 3}}, true},
+		{"Limits",
+			[]models.CapacityProviderStrategyItem{{CapacityProvider: "my-asg-provider", Base: 100000, Weight: 1000}}, true},
+		{"Missing capacity provider name",
+			[]models.CapacityProviderStrategyItem{{Weight: 1}}, false},
+		{"Base too large",
+			[]models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE", Base: 100001, Weight: 1}}, false},
+		{"Negative weight",
+			[]models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE", Weight: -1}}, false},
+		{"Weight too large",
+			[]models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE", Weight: 1001}}, false},
+		{"Two providers with a base",
+			[]models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE", Base: 1, Weight: 1}, {CapacityProvider: "FARGATE_SPOT", Base: 2, Weight: 1}}, false},
+	}
+
+	for _, test := range tests {
+		if output := validateEcsCapacityProviderStrategy(test.capacityProviderStrategy, "web:3"); output != test.expectedOutput {
+			t.Errorf("The validateEcsCapacityProviderStrategy function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test validateEcsPlatformVersion
+func TestValidateEcsPlatformVersion(t *testing.T) {
+	var tests = []struct {
+		platformVersion string
+		expectedOutput  bool
+	}{
+		{"LATEST", true},
+		{"1.4.0", true},
+		{"1.3.0", true},
+		{"[Version number, ex: 1.3.0]", true},
+		{"latest", false},
+		{"1.5", false},
+		{"1.0.0", false},
+	}
+
+	for _, test := range tests {
+		if output := validateEcsPlatformVersion(test.platformVersion, nil, "web:3"); output != test.expectedOutput {
+			t.Errorf("The validateEcsPlatformVersion function returned %v for: %v", output, test.platformVersion)
+		}
+	}
+}
+
+// Test getEcsLaunchType
+func TestGetEcsLaunchType(t *testing.T) {
+	var tests = []struct {
+		name                     string
+		capacityProviderStrategy []models.CapacityProviderStrategyItem
+		requiresCompatibilities  []string
+		expected                 string
+	}{
+		{"FARGATE_SPOT capacity provider", []models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE_SPOT", Weight: 1}}, nil, fargateLaunchType},
+		{"Auto Scaling group capacity provider", []models.CapacityProviderStrategyItem{{CapacityProvider: "my-asg-provider", Weight: 1}}, nil, ec2LaunchType},
+		{"Placeholder capacity provider", []models.CapacityProviderStrategyItem{{CapacityProvider: "[Your capacity provider]", Weight: 1}}, nil, unknownLaunchType},
+		{"No capacity provider or task definition", nil, nil, unknownLaunchType},
+		{"Fargate task definition", nil, []string{"EC2", "FARGATE"}, fargateLaunchType},
+		{"EC2 task definition", nil, []string{"EC2"}, ec2LaunchType},
+		{"Capacity provider wins over the task definition", []models.CapacityProviderStrategyItem{{CapacityProvider: "FARGATE", Weight: 1}}, []string{"EC2"}, fargateLaunchType},
+	}
+
+	defer func() { ecsTaskDefinition = nil }()
+	for _, test := range tests {
+		ecsTaskDefinition = nil
+		if test.requiresCompatibilities != nil {
+			ecsTaskDefinition = &models.EcsTaskDefinitionModel{RequiresCompatibilities: test.requiresCompatibilities}
+		}
+		if output := getEcsLaunchType(test.capacityProviderStrategy); output != test.expected {
+			t.Errorf("The getEcsLaunchType function returned %q but should have returned %q for: %v", output, test.expected, test.name)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package assistant
+
+import (
+	"fmt"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/globalVars"
+)
+
+// Validate the Lambda function of an ECS or Lambda hook
+// Function name, partial ARN, or function ARN, each with an optional version or alias
+func validateHookFunction(hook string, function string) bool {
+	if isAppSpecPlaceholder(function) {
+		return true
+	}
+
+	if !isValidLambdaFunctionReference(function, true) {
+		numOfErrors++
+		fmt.Printf(errorHandling.InvalidHookFunctionErr, function, hook)
+		return false
+	}
+
+	if !strings.HasPrefix(getLambdaFunctionName(function), globalVars.HookFunctionNamePrefix) {
+		fmt.Printf(errorHandling.MissingHookFunctionPrefixWarn, globalVars.HookFunctionNamePrefix, function, hook)
+	}
+
+	return true
+}
+
+// Each hook can only be listed once in the Hooks array
+func validateUniqueHooks(hooks []map[string]string) bool {
+	var hookNames []string
+	for _, hook := range hooks {
+		for hookName := range hook {
+			hookNames = append(hookNames, hookName)
+		}
+	}
+
+	if duplicateHooks := getDuplicateValues(hookNames); len(duplicateHooks) > 0 {
+		numOfErrors++
+		fmt.Println(errorHandling.DuplicateHooksErr, duplicateHooks)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,53 @@
+package assistant
+
+import (
+	"testing"
+)
+
+// Test validateHookFunction
+func TestValidateHookFunction(t *testing.T) {
+	var tests = []struct {
+		function       string
+		expectedOutput bool
+	}{
+		{"CodeDeployHook_BeforeAllowTraffic", true},
+		{"CodeDeployHook_BeforeAllowTraffic:live", true},
+		{"CodeDeployHook_BeforeAllowTraffic:$LATEST", true},
+		{"111122223333:function:CodeDeployHook_BeforeAllowTraffic:3", true},
+		{"arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeAllowTraffic:live", true},
+		{"SanityTest", true},
+		{"<SanityTestHookLambdaFunctionName>", true},
+		{"CodeDeployHook_BeforeAllowTraffic:live:3", false},
+		{"CodeDeployHook_BeforeAllowTraffic:my.alias", false},
+		{"arn:aws:lambda:us-east-1:111122223333:function:CodeDeployHook_BeforeAllowTraffic:live:3", false},
+		{"arn:aws:s3:::CodeDeployHook_BeforeAllowTraffic", false},
+	}
+
+	for _, test := range tests {
+		if output := validateHookFunction("BeforeAllowTraffic", test.function); output != test.expectedOutput {
+			t.Errorf("The validateHookFunction function returned %v for: %v", output, test.function)
+		}
+	}
+}
+
+// Test validateUniqueHooks
+func TestValidateUniqueHooks(t *testing.T) {
+	var tests = []struct {
+		name           string
+		hooks          []map[string]string
+		expectedOutput bool
+	}{
+		{"Unique hooks",
+			[]map[string]string{{"BeforeAllowTraffic": "CodeDeployHook_A"}, {"AfterAllowTraffic": "CodeDeployHook_B"}}, true},
+		{"Same function for two hooks",
+			[]map[string]string{{"BeforeAllowTraffic": "CodeDeployHook_A"}, {"AfterAllowTraffic": "CodeDeployHook_A"}}, true},
+		{"Hook listed twice",
+			[]map[string]string{{"BeforeAllowTraffic": "CodeDeployHook_A"}, {"AfterAllowTraffic": "CodeDeployHook_B"}, {"BeforeAllowTraffic": "CodeDeployHook_C"}}, false},
+	}
+
+	for _, test := range tests {
+		if output := validateUniqueHooks(test.hooks); output != test.expectedOutput {
+			t.Errorf("The validateUniqueHooks function returned %v for: %v", output, test.name)
+		}
+	}
+}
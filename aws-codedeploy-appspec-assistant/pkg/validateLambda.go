@@ -76,6 +76,11 @@ func validateLambdaResources(lambdaResources []map[string]models.Function) bool
 				fmt.Println(errorHandling.EmptyLambdaResourceFunctionNameErr)
 			}
 
+			// The resource name is only a label, the function is Properties -> Name
+			if functionResourceName != "" && function.Properties.Name != "" && getLambdaFunctionName(function.Properties.Name) != functionResourceName {
+				fmt.Printf(errorHandling.MismatchedLambdaResourceNameWarn, functionResourceName, function.Properties.Name)
+			}
+
 			// Function Type
 			if function.Type != "AWS::Lambda::Function" {
 				resourcesValid = false
@@ -101,29 +106,94 @@ func validateLambdaResourceProperties(lambdaProperties models.LambdaProperties,
 		propertiesValid = false
 		numOfErrors++
 		fmt.Println(errorHandling.EmptyLambdaFunctionNameErr, functionResourceName)
+	} else if !isAppSpecPlaceholder(lambdaProperties.Name) && !validateLambdaFunctionName(lambdaProperties.Name, functionResourceName) {
+		propertiesValid = false
 	}
 
 	if lambdaProperties.Alias == "" {
 		propertiesValid = false
 		numOfErrors++
 		fmt.Println(errorHandling.EmptyLambdaFunctionAliasErr, functionResourceName)
+	} else if !isAppSpecPlaceholder(lambdaProperties.Alias) && !validateLambdaFunctionAlias(lambdaProperties.Alias, functionResourceName) {
+		propertiesValid = false
 	}
 
 	if lambdaProperties.CurrentVersion == "" {
 		propertiesValid = false
 		numOfErrors++
 		fmt.Println(errorHandling.EmptyLambdaFunctionCurrVersionErr, functionResourceName)
+	} else if !isAppSpecPlaceholder(lambdaProperties.CurrentVersion) && !validateLambdaFunctionVersion("CurrentVersion", lambdaProperties.CurrentVersion, functionResourceName) {
+		propertiesValid = false
 	}
 
 	if lambdaProperties.TargetVersion == "" {
 		propertiesValid = false
 		numOfErrors++
 		fmt.Println(errorHandling.EmptyLambdaFunctionTargetVersionErr, functionResourceName)
+	} else if !isAppSpecPlaceholder(lambdaProperties.TargetVersion) && !validateLambdaFunctionVersion("TargetVersion", lambdaProperties.TargetVersion, functionResourceName) {
+		propertiesValid = false
+	}
+
+	// CodeDeploy has nothing to shift traffic to
+	if lambdaProperties.CurrentVersion != "" && lambdaProperties.CurrentVersion == lambdaProperties.TargetVersion {
+		propertiesValid = false
+		numOfErrors++
+		fmt.Printf(errorHandling.SameLambdaFunctionVersionsErr, lambdaProperties.CurrentVersion, functionResourceName)
+	}
+
+	// Cross-check with the inventory (Optional)
+	if lambdaInventory != nil && lambdaProperties.Name != "" && !validateLambdaInventory(lambdaProperties, functionResourceName) {
+		propertiesValid = false
 	}
 
 	return propertiesValid
 }
 
+// Function name, partial ARN, or function ARN without a qualifier
+func validateLambdaFunctionName(functionName string, functionResourceName string) bool {
+	if len(getLambdaFunctionName(functionName)) > globalVars.LambdaMaxFunctionNameLength {
+		numOfErrors++
+		fmt.Printf(errorHandling.LongLambdaFunctionNameErr, globalVars.LambdaMaxFunctionNameLength, functionResourceName)
+		return false
+	}
+
+	if !isValidLambdaFunctionReference(functionName, false) {
+		numOfErrors++
+		fmt.Printf(errorHandling.InvalidLambdaFunctionNameErr, functionName, functionResourceName)
+		return false
+	}
+
+	return true
+}
+
+// Alias names can not be only digits so they are not confused with versions
+func validateLambdaFunctionAlias(alias string, functionResourceName string) bool {
+	if lambdaNumericAliasPattern.MatchString(alias) {
+		numOfErrors++
+		fmt.Printf(errorHandling.NumericLambdaFunctionAliasErr, alias, functionResourceName)
+		return false
+	}
+
+	if len(alias) > globalVars.LambdaMaxAliasLength || !lambdaAliasPattern.MatchString(alias) {
+		numOfErrors++
+		fmt.Printf(errorHandling.InvalidLambdaFunctionAliasErr, globalVars.LambdaMaxAliasLength, alias, functionResourceName)
+		return false
+	}
+
+	return true
+}
+
+// CodeDeploy shifts traffic between published versions, not $LATEST or aliases
+func validateLambdaFunctionVersion(versionName string, version string, functionResourceName string) bool {
+	if !lambdaVersionPattern.MatchString(version) {
+		numOfErrors++
+		fmt.Printf(errorHandling.InvalidLambdaFunctionVersionErr, versionName, version, functionResourceName)
+		return false
+	}
+
+	return true
+}
+
 // Lambda Hooks validation method
 // Validate Hooks object
 func validateLambdaHooks(lambdaHooks []map[string]string) bool {
@@ -137,12 +207,18 @@ func validateLambdaHooks(lambdaHooks []map[string]string) bool {
 					fmt.Println(errorHandling.EmptyLambdaHookValErr, hook)
 					numOfErrors++
 					hooksValid = false
+				} else if !validateHookFunction(hook, val) {
+					hooksValid = false
 				}
 				numValidHooks++
 			}
 		}
 	}
 
+	if !validateUniqueHooks(lambdaHooks) {
+		hooksValid = false
+	}
+
 	if numValidHooks != len(lambdaHooks) {
 		numOfErrors++
 		hooksValid = false
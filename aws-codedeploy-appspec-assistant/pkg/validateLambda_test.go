@@ -2,6 +2,7 @@ package assistant
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"aws-codedeploy-appspec-assistant/models"
@@ -257,3 +258,59 @@ func TestValidateLambdaResources_InvalidInput(t *testing.T) {
 		}
 	}
 }
+
+// Test validateLambdaResourceProperties
+func TestValidateLambdaResourceProperties(t *testing.T) {
+	var tests = []struct {
+		name             string
+		lambdaProperties models.LambdaProperties
+		expectedOutput   bool
+	}{
+		{"Function name",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "2"}, true},
+		{"Function ARN",
+			models.LambdaProperties{Name: "arn:aws-cn:lambda:cn-north-1:111122223333:function:myLambdaFunction", Alias: "live", CurrentVersion: "9", TargetVersion: "10"}, true},
+		{"Unsubstituted versions",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "${CURRENT_VERSION}", TargetVersion: "${TARGET_VERSION}"}, true},
+		{"Same versions",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "2", TargetVersion: "2"}, false},
+		{"$LATEST TargetVersion",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "$LATEST"}, false},
+		{"Non-numeric CurrentVersion",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "v1", TargetVersion: "2"}, false},
+		{"Version 0",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "live", CurrentVersion: "0", TargetVersion: "2"}, false},
+		{"Alias that looks like a version",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "2", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Alias 0",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "0", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Alias with leading zeros",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "007", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Alias with a leading zero",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "01", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Alias with digits and letters",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "v007", CurrentVersion: "1", TargetVersion: "2"}, true},
+		{"Alias with invalid characters",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: "my.alias", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Alias too long",
+			models.LambdaProperties{Name: "myLambdaFunction", Alias: strings.Repeat("a", 129), CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Name too long",
+			models.LambdaProperties{Name: strings.Repeat("f", 65), Alias: "live", CurrentVersion: "1", TargetVersion: "2"}, false},
+		{"Malformed ARN",
+			models.LambdaProperties{Name: "arn:aws:lambda:us-east-1:function:myLambdaFunction", Alias: "live", CurrentVersion: "1", TargetVersion: "2"}, false},
+	}
+
+	for _, test := range tests {
+		if output := validateLambdaResourceProperties(test.lambdaProperties, "myLambdaFunction"); output != test.expectedOutput {
+			t.Errorf("The validateLambdaResourceProperties function returned %v for: %v", output, test.name)
+		}
+	}
+}
+
+// Test validateLambdaHooks
+func TestValidateLambdaHooks_DuplicateHook(t *testing.T) {
+	lambdaHooks := []map[string]string{{"BeforeAllowTraffic": "CodeDeployHook_Sanity"}, {"BeforeAllowTraffic": "CodeDeployHook_Validation"}}
+	if validateLambdaHooks(lambdaHooks) {
+		t.Errorf("The validateLambdaHooks function succeeded but should have failed for a hook listed twice")
+	}
+}
@@ -52,16 +52,24 @@ func validateServerAppSpec(serverAppSpecModel models.ServerAppSpecModel) error {
 		if !validateServerFiles(serverAppSpecModel.Files) {
 			err = fmt.Errorf(errorHandling.InvalidServerFileSpecsErr)
 			fmt.Println(err)
+		} else if serverRevision != nil && !validateServerRevisionFiles(serverAppSpecModel.Files, serverAppSpecModel.OS) {
+			err = fmt.Errorf(errorHandling.InvalidServerFileSpecsErr)
+			fmt.Println(err)
 		}
 	}
 
 	// Permissions (Optional)
 	if serverAppSpecModel.Permissions != nil && len(serverAppSpecModel.Permissions) > 0 {
-		// All other values are optionsl
-		fmt.Println("\nWARNING: All options besides Object are optional for permissions so there is very little to validate automatically.")
-		if !validateServerPermissions(serverAppSpecModel.Permissions) {
+		if serverAppSpecModel.OS == "windows" {
+			numOfErrors++
+			fmt.Println(errorHandling.WindowsServerPermissionsErr)
+			err = fmt.Errorf(errorHandling.InvalidServerPermissionsErr)
+			fmt.Println(err)
+		} else if !validateServerPermissions(serverAppSpecModel.Permissions) {
 			err = fmt.Errorf(errorHandling.InvalidServerPermissionsErr)
 			fmt.Println(err)
+		} else {
+			validateServerPermissionObjects(serverAppSpecModel.Permissions, serverAppSpecModel.Files)
 		}
 	}
 
@@ -71,6 +79,12 @@ func validateServerAppSpec(serverAppSpecModel models.ServerAppSpecModel) error {
 		if !validateServerHooks(serverAppSpecModel.Hooks) {
 			err = fmt.Errorf(errorHandling.InvalidServerHooksErr)
 			fmt.Println(err)
+		} else if serverRevision != nil && !validateServerRevisionHookScripts(serverAppSpecModel.Hooks, serverAppSpecModel.OS) {
+			err = fmt.Errorf(errorHandling.InvalidServerHooksErr)
+			fmt.Println(err)
+		} else if serverRevision != nil && !lintServerHookScripts(serverAppSpecModel.Hooks, serverAppSpecModel.OS) {
+			err = fmt.Errorf(errorHandling.InvalidServerHooksErr)
+			fmt.Println(err)
 		}
 	}
 
@@ -133,6 +147,12 @@ func validateServerPermissions(permissions []models.Permission) bool {
 				}
 			}
 		}
+
+		for _, permissionErr := range getServerPermissionErrs(permission) {
+			permissionsValid = false
+			numOfErrors++
+			fmt.Print(permissionErr)
+		}
 	}
 
 	return permissionsValid
@@ -160,7 +180,19 @@ func validateServerHooks(serverHooks map[string][]models.Hook) bool {
 		}
 	}
 
-	if withLBHooksUsed {
+	// The lifecycle events CodeDeploy runs itself
+	numReservedHooks := 0
+	for _, hook := range globalVars.AppSpecServerReservedLifecycleEvents {
+		if _, ok := serverHooks[hook]; ok {
+			numOfErrors++
+			fmt.Printf(errorHandling.ReservedServerHookErr, hook, globalVars.AppSpecServerReservedLifecycleEvents)
+			numReservedHooks++
+		}
+	}
+
+	if serverDeployment != nil {
+		validateServerDeploymentHooks(serverHooks, *serverDeployment)
+	} else if withLBHooksUsed {
 		fmt.Println("\nWARNING: EC2/On-Prem (Server) hooks for LoadBalancers used, so the deployments should use a LoadBalancer for these scripts to be run.")
 	}
 
@@ -168,7 +200,7 @@ func validateServerHooks(serverHooks map[string][]models.Hook) bool {
 		return true
 	}
 
-	if !(numValidHooks == len(serverHooks)) {
+	if !(numValidHooks+numReservedHooks == len(serverHooks)) {
 		numOfErrors++
 		fmt.Println(errorHandling.UnsupportedServerHooksErr)
 		fmt.Printf(errorHandling.SupportedServerHooksWithoutLBStr, globalVars.AppSpecSupportedServerHooksWithoutLB)
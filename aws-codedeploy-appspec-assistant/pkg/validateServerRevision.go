@@ -0,0 +1,125 @@
+package assistant
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"aws-codedeploy-appspec-assistant/errorHandling"
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+// Set by the validate command
+// Server only: revision directory or archive the files sources and script locations must exist in
+var ServerRevisionPath string
+
+// Revision the server AppSpec is checked against, nil if there is none
+var serverRevision *appSpecRevision
+
+// Load the revision to check the server AppSpec against
+func setupServerRevision() error {
+	serverRevision = nil
+
+	if ServerRevisionPath == "" {
+		return nil
+	}
+
+	revision, err := loadAppSpecRevision(ServerRevisionPath)
+	if err != nil {
+		return err
+	}
+	serverRevision = revision
+
+	return nil
+}
+
+// Validate every files source exists in the revision, relative to the AppSpec root
+// A source of / is the whole revision
+func validateServerRevisionFiles(files []models.File, appSpecOS string) bool {
+	filesValid := true
+
+	for _, file := range files {
+		source := cleanRevisionPath(file.Source)
+		if file.Source == "" || source == "" {
+			continue
+		}
+
+		entry, ok := findServerRevisionEntry(source, appSpecOS)
+		if !ok {
+			filesValid = false
+			if entry.path == "" {
+				numOfErrors++
+				fmt.Println(errorHandling.MissingServerFileSourceInRevisionErr, file.Source)
+			}
+		}
+	}
+
+	return filesValid
+}
+
+// Validate every script location exists in the revision, relative to the AppSpec root, and is a regular file
+func validateServerRevisionHookScripts(serverHooks map[string][]models.Hook, appSpecOS string) bool {
+	scriptsValid := true
+
+	for _, hook := range getSortedHookKeys(serverHooks) {
+		for _, hookScript := range serverHooks[hook] {
+			if hookScript.Location == "" {
+				continue
+			}
+
+			entry, ok := findServerRevisionEntry(cleanRevisionPath(hookScript.Location), appSpecOS)
+			if !ok {
+				scriptsValid = false
+				if entry.path == "" {
+					numOfErrors++
+					fmt.Printf(errorHandling.MissingServerHookScriptInRevisionErr, hookScript.Location, hook)
+				}
+				continue
+			}
+
+			if !entry.mode.IsRegular() {
+				numOfErrors++
+				fmt.Printf(errorHandling.InvalidServerHookScriptTypeErr, getRevisionEntryType(entry), hookScript.Location, hook)
+				scriptsValid = false
+			}
+		}
+	}
+
+	return scriptsValid
+}
+
+// Find a path relative to the AppSpec root
+// A path that only exists with a different case works on Windows instances but not on Linux instances
+// Returns an empty entry if the path does not exist at all, which is left for the caller to report
+func findServerRevisionEntry(relativePath string, appSpecOS string) (revisionEntry, bool) {
+	if entry, ok := serverRevision.getEntry(relativePath); ok {
+		return entry, true
+	}
+
+	foundPath, found := serverRevision.findEntryIgnoringCase(relativePath)
+	if !found {
+		return revisionEntry{}, false
+	}
+	entry, _ := serverRevision.getEntry(foundPath)
+
+	if appSpecOS == "windows" {
+		fmt.Printf(errorHandling.CaseMismatchedRevisionPathWarn, relativePath, foundPath)
+		return entry, true
+	}
+
+	numOfErrors++
+	fmt.Printf(errorHandling.CaseMismatchedRevisionPathErr, relativePath, foundPath)
+
+	return entry, false
+}
+
+func getRevisionEntryType(entry revisionEntry) string {
+	switch {
+	case entry.mode.IsDir():
+		return "directory"
+	case entry.mode&os.ModeSymlink != 0:
+		return "symlink to " + entry.linkTarget
+	default:
+		return strings.TrimSpace(entry.mode.Type().String())
+	}
+}
@@ -0,0 +1,99 @@
+package assistant
+
+import (
+	"os"
+	"testing"
+
+	"aws-codedeploy-appspec-assistant/models"
+)
+
+func setupTestServerRevision(t *testing.T) func() {
+	revisionDirPath := writeTestRevisionDir(t, map[string]string{
+		"appspec.yml":            "version: 0.0\n",
+		"app/index.html":         "<html></html>",
+		"Scripts/start.sh":       "#!/bin/sh\n",
+		"scripts/stop.sh":        "#!/bin/sh\n",
+		"scripts/lib/helpers.sh": "#!/bin/sh\n",
+	})
+
+	revision, err := loadAppSpecRevision(revisionDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverRevision = revision
+
+	return func() {
+		serverRevision = nil
+		os.RemoveAll(revisionDirPath)
+	}
+}
+
+// Test validateServerRevisionFiles
+func TestValidateServerRevisionFiles(t *testing.T) {
+	defer setupTestServerRevision(t)()
+
+	var tests = []struct {
+		name      string
+		files     []models.File
+		appSpecOS string
+		expected  bool
+	}{
+		{"File and directory sources", []models.File{{"app/index.html", "/var/www"}, {"/app/", "/var/www"}}, "linux", true},
+		{"Whole revision", []models.File{{"/", "/opt/app"}}, "linux", true},
+		{"Missing source", []models.File{{"dist", "/var/www"}}, "linux", false},
+		{"Case mismatch on Linux", []models.File{{"scripts/start.sh", "/opt/app"}}, "linux", false},
+		{"Case mismatch on Windows", []models.File{{"scripts/start.sh", "C:\\app"}}, "windows", true},
+	}
+
+	for _, test := range tests {
+		if valid := validateServerRevisionFiles(test.files, test.appSpecOS); valid != test.expected {
+			t.Errorf("The validateServerRevisionFiles function returned %v instead of %v for: %v", valid, test.expected, test.name)
+		}
+	}
+}
+
+// Test validateServerRevisionHookScripts
+func TestValidateServerRevisionHookScripts(t *testing.T) {
+	defer setupTestServerRevision(t)()
+
+	var tests = []struct {
+		name      string
+		location  string
+		appSpecOS string
+		expected  bool
+	}{
+		{"Script exists", "scripts/stop.sh", "linux", true},
+		{"Script with leading slash", "/scripts/lib/helpers.sh", "linux", true},
+		{"Missing script", "scripts/missing.sh", "linux", false},
+		{"Directory instead of a script", "scripts/lib", "linux", false},
+		{"Case mismatch on Linux", "scripts/start.sh", "linux", false},
+		{"Case mismatch on Windows", "scripts/start.sh", "windows", true},
+	}
+
+	for _, test := range tests {
+		hooks := map[string][]models.Hook{"ApplicationStart": {{Location: test.location}}}
+		if valid := validateServerRevisionHookScripts(hooks, test.appSpecOS); valid != test.expected {
+			t.Errorf("The validateServerRevisionHookScripts function returned %v instead of %v for: %v", valid, test.expected, test.name)
+		}
+	}
+}
+
+// Test the revision checks are part of validateServerAppSpec
+func TestValidateServerAppSpec_Revision(t *testing.T) {
+	defer setupTestServerRevision(t)()
+
+	serverAppSpecModel := models.ServerAppSpecModel{
+		OS:    "linux",
+		Files: []models.File{{Source: "app", Destination: "/var/www"}},
+		Hooks: map[string][]models.Hook{"ApplicationStart": {{Location: "scripts/missing.sh"}}},
+	}
+
+	if err := validateServerAppSpec(serverAppSpecModel); err == nil {
+		t.Errorf("The validateServerAppSpec function succeeded for a script that is not in the revision")
+	}
+
+	serverAppSpecModel.Hooks["ApplicationStart"][0].Location = "scripts/stop.sh"
+	if err := validateServerAppSpec(serverAppSpecModel); err != nil {
+		t.Errorf("The validateServerAppSpec function failed for a valid revision: %v", err)
+	}
+}
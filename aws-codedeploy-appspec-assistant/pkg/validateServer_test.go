@@ -55,6 +55,29 @@ func TestValidateServerAppSpec_ValidInput(t *testing.T) {
 	}
 }
 
+func TestValidateServerAppSpec_InvalidInput(t *testing.T) {
+	var tests = []struct {
+		name         string
+		fileStrInput string
+	}{
+		{"Permissions with os windows",
+			"version: 0.0\nos: windows\nfiles:\n  - source: app\n    destination: C:\\app\npermissions:\n  - object: C:\\app\n    mode: 644\n"},
+		{"Invalid permissions mode",
+			"version: 0.0\nos: linux\nfiles:\n  - source: app\n    destination: /var/www\npermissions:\n  - object: /var/www\n    mode: 888\n"},
+	}
+
+	for _, test := range tests {
+		fileExtension = "yml"
+		appSpecModel, modelErr := getServerAppSpecObjFromString([]byte(test.fileStrInput))
+		if modelErr != nil {
+			t.Errorf("getServerAppSpecObjFromString FAILED")
+		}
+		if err := validateServerAppSpec(appSpecModel); err == nil {
+			t.Errorf("The validateServerAppSpec function succeeded but should have failed for: %v", test.name)
+		}
+	}
+}
+
 // Test checkOS
 func TestCheckOS_ValidInput(t *testing.T) {
 	var tests = []struct {